@@ -0,0 +1,542 @@
+package vers
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// BumpKind selects which part of a version Bump increments.
+type BumpKind int
+
+const (
+	// BumpMajor increments the major version and zeroes the numbers after it.
+	BumpMajor BumpKind = iota
+	// BumpMinor increments the minor version and zeroes the numbers after it.
+	BumpMinor
+	// BumpPatch increments the patch version.
+	BumpPatch
+	// BumpPremajor, BumpPreminor and BumpPrepatch increment the release and
+	// start its first prerelease.
+	BumpPremajor
+	BumpPreminor
+	BumpPrepatch
+	// BumpPrerelease increments a prerelease, or starts the next patch's
+	// first prerelease when the version is final.
+	BumpPrerelease
+	// BumpRelease turns a prerelease into its final release.
+	BumpRelease
+	// BumpPost increments a PEP 440 post-release.
+	BumpPost
+	// BumpRevision increments a Debian revision or RPM release.
+	BumpRevision
+)
+
+// String returns the lowercase name of the bump kind.
+func (k BumpKind) String() string {
+	names := []string{"major", "minor", "patch", "premajor", "preminor", "prepatch", "prerelease", "release", "post", "revision"}
+	if k >= 0 && int(k) < len(names) {
+		return names[k]
+	}
+	return fmt.Sprintf("BumpKind(%d)", int(k))
+}
+
+// BumpOption adjusts how Bump increments a version.
+type BumpOption func(*bumpOptions)
+
+type bumpOptions struct {
+	identifier string
+}
+
+// PrereleaseIdentifier names the prerelease to start or continue, such as
+// "beta" for npm, "rc" or "dev" for PyPI, or "alpha" for Maven.
+func PrereleaseIdentifier(identifier string) BumpOption {
+	return func(o *bumpOptions) {
+		o.identifier = identifier
+	}
+}
+
+// Bump returns the next version of the given kind under a scheme's
+// conventions:
+//   - npm, semver, cargo, pub, hex and go: node-semver's inc, so
+//     1.2.3 premajor beta is 2.0.0-beta.0 and 2.0.0-rc.1 major is 2.0.0
+//   - pypi: dev releases move to pre-releases and then the final release,
+//     post-releases count up, and the epoch is kept
+//   - maven and gradle: the qualifier's number counts up, and a final
+//     release moves to the next patch's SNAPSHOT
+//   - gem: segments count up and prerelease segments such as rc1 advance
+//   - deb and rpm: ~ marks prereleases, and BumpRevision increments the
+//     Debian revision or RPM release
+//
+// The result always sorts after version under CompareWithScheme; a bump
+// that would not, such as moving from rc to alpha, is an error.
+func Bump(version, scheme string, kind BumpKind, opts ...BumpOption) (string, error) {
+	var options bumpOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	version = strings.TrimSpace(version)
+	scheme = canonicalScheme(scheme)
+	if !validVersionForScheme(version, scheme) {
+		return "", fmt.Errorf("invalid %s version: %s", scheme, version)
+	}
+
+	var bumped string
+	var err error
+	switch scheme {
+	case schemeNPM, schemeSemVer, schemeCargo, schemePub, schemeHex, schemeGo:
+		bumped, err = bumpSemver(version, scheme, kind, options.identifier)
+	case schemePyPI:
+		bumped, err = bumpPEP440(version, kind, options.identifier)
+	case schemeMaven, schemeGradle:
+		bumped, err = bumpMaven(version, scheme, kind, options.identifier)
+	case schemeGem:
+		bumped, err = bumpGem(version, kind, options.identifier)
+	case schemeDeb, schemeRPM:
+		bumped, err = bumpPackageVersion(version, scheme, kind, options.identifier)
+	default:
+		return "", fmt.Errorf("bumping %s versions is not supported", scheme)
+	}
+	if err != nil {
+		return "", err
+	}
+	if CompareWithScheme(bumped, version, scheme) <= 0 {
+		return "", fmt.Errorf("%s bump of %s gives %s, which does not sort after it", kind, version, bumped)
+	}
+	return bumped, nil
+}
+
+func unsupportedBump(kind BumpKind, scheme string) error {
+	return fmt.Errorf("%s bumps are not supported for %s versions", kind, scheme)
+}
+
+func notPrerelease(version string) error {
+	return fmt.Errorf("version %s is not a prerelease", version)
+}
+
+// bumpIndex returns the release number a major, minor or patch bump increments.
+func bumpIndex(kind BumpKind) int {
+	switch kind {
+	case BumpMajor, BumpPremajor:
+		return 0
+	case BumpMinor, BumpPreminor:
+		return 1
+	}
+	return 2 //nolint:mnd
+}
+
+// bumpRelease increments release[index] and zeroes the numbers after it.
+// A prerelease whose numbers after index are already zero is finalized
+// instead, as node-semver does: 2.0.0-rc.1 bumps to 2.0.0.
+func bumpRelease(release []string, index int, prerelease bool) []string {
+	if prerelease {
+		finalized := true
+		for i := index + 1; i < len(release); i++ {
+			if releasePart(release, i) != "0" {
+				finalized = false
+			}
+		}
+		if finalized {
+			return release
+		}
+	}
+	bumped := make([]string, max(len(release), index+1))
+	for i := range bumped {
+		switch {
+		case i < index:
+			bumped[i] = release[i]
+		case i == index:
+			bumped[i] = incNumStr(releasePart(release, i))
+		default:
+			bumped[i] = "0"
+		}
+	}
+	return bumped
+}
+
+// incTrailingNumber increments the number at the end of s.
+func incTrailingNumber(s string) (string, bool) {
+	start := len(s)
+	for start > 0 && isASCIIDigit(s[start-1]) {
+		start--
+	}
+	if start == len(s) {
+		return s, false
+	}
+	return s[:start] + incNumStr(trimLeadingZeros(s[start:])), true
+}
+
+// prereleaseName strips the counter from a prerelease label: rc.1, rc-1
+// and rc1 are all named rc.
+func prereleaseName(label string) string {
+	return strings.TrimRight(label, "0123456789.-")
+}
+
+func bumpSemver(version, scheme string, kind BumpKind, identifier string) (string, error) {
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix = "v"
+	}
+	m := SemanticVersionRegex.FindStringSubmatch(version)
+	release := []string{m[1], m[2], m[3]}
+	for i := range release {
+		if release[i] == "" {
+			release[i] = "0"
+		}
+	}
+	var pre []string
+	if m[4] != "" {
+		pre = strings.Split(m[4], ".")
+	}
+
+	switch kind {
+	case BumpMajor, BumpMinor, BumpPatch:
+		release = bumpRelease(release, bumpIndex(kind), len(pre) > 0)
+		pre = nil
+	case BumpPremajor, BumpPreminor, BumpPrepatch:
+		release = bumpRelease(release, bumpIndex(kind), false)
+		pre = incSemverPrerelease(nil, identifier)
+	case BumpPrerelease:
+		if len(pre) == 0 {
+			release = bumpRelease(release, bumpIndex(BumpPatch), false)
+		}
+		pre = incSemverPrerelease(pre, identifier)
+	case BumpRelease:
+		if len(pre) == 0 {
+			return "", notPrerelease(version)
+		}
+		pre = nil
+	default:
+		return "", unsupportedBump(kind, scheme)
+	}
+
+	bumped := prefix + strings.Join(release, ".")
+	if len(pre) > 0 {
+		bumped += "-" + strings.Join(pre, ".")
+	}
+	if scheme == schemeGo && m[5] == "incompatible" {
+		bumped += goIncompatibleSuffix
+	}
+	return bumped, nil
+}
+
+// incSemverPrerelease follows node-semver's inc("pre"): the last numeric
+// identifier counts up, or .0 is appended, and a different identifier
+// starts over at identifier.0.
+func incSemverPrerelease(pre []string, identifier string) []string {
+	next := append([]string(nil), pre...)
+	if len(next) == 0 {
+		next = []string{"0"}
+	} else {
+		incremented := false
+		for i := len(next) - 1; i >= 0 && !incremented; i-- {
+			if isDigits(next[i]) {
+				next[i] = incNumStr(trimLeadingZeros(next[i]))
+				incremented = true
+			}
+		}
+		if !incremented {
+			next = append(next, "0")
+		}
+	}
+	if identifier == "" {
+		return next
+	}
+	if next[0] != identifier || len(next) < 2 || !isDigits(next[1]) { //nolint:mnd
+		return []string{identifier, "0"}
+	}
+	return next
+}
+
+func bumpPEP440(version string, kind BumpKind, identifier string) (string, error) {
+	v, _ := parsePEP440(version)
+	v.local = nil
+	prerelease := v.hasPre || v.hasDev
+
+	switch kind {
+	case BumpMajor, BumpMinor, BumpPatch:
+		// A dev release of a post-release comes before that post-release,
+		// not before the final release, so it is never finalized here.
+		v.release = bumpRelease(v.release, bumpIndex(kind), prerelease && !v.hasPost)
+		clearPEP440Suffixes(&v)
+	case BumpPremajor, BumpPreminor, BumpPrepatch:
+		v.release = bumpRelease(v.release, bumpIndex(kind), false)
+		clearPEP440Suffixes(&v)
+		if err := startPEP440Prerelease(&v, identifier); err != nil {
+			return "", err
+		}
+	case BumpPrerelease:
+		if err := bumpPEP440Prerelease(&v, identifier); err != nil {
+			return "", err
+		}
+	case BumpRelease:
+		if !prerelease {
+			return "", notPrerelease(version)
+		}
+		v.hasPre, v.preNum = false, ""
+		v.hasDev, v.dev = false, ""
+	case BumpPost:
+		switch {
+		case v.hasPost && v.hasDev:
+			v.hasDev, v.dev = false, ""
+		case v.hasPost:
+			v.post = incNumStr(trimLeadingZeros(v.post))
+		default:
+			v.hasPost, v.post = true, "1"
+			v.hasDev, v.dev = false, ""
+		}
+	default:
+		return "", unsupportedBump(kind, schemePyPI)
+	}
+	return formatPEP440(v), nil
+}
+
+func clearPEP440Suffixes(v *pep440Version) {
+	v.hasPre, v.preNum = false, ""
+	v.hasPost, v.post = false, ""
+	v.hasDev, v.dev = false, ""
+}
+
+// pep440Phase maps a prerelease identifier to a PEP 440 pre-release tag,
+// or reports that it names a dev release.
+func pep440Phase(identifier string) (tag int, dev bool, err error) {
+	if identifier == "" {
+		return 0, false, nil
+	}
+	identifier = strings.ToLower(identifier)
+	if identifier == "dev" {
+		return 0, true, nil
+	}
+	tag, ok := pep440PreTags[identifier]
+	if !ok {
+		return 0, false, fmt.Errorf("invalid PEP 440 prerelease identifier: %s", identifier)
+	}
+	return tag, false, nil
+}
+
+// startPEP440Prerelease starts the first pre-release, or dev release, of
+// v's release.
+func startPEP440Prerelease(v *pep440Version, identifier string) error {
+	tag, dev, err := pep440Phase(identifier)
+	if err != nil {
+		return err
+	}
+	if dev {
+		v.hasDev, v.dev = true, "0"
+		return nil
+	}
+	v.hasPre, v.preTag, v.preNum = true, tag, "1"
+	return nil
+}
+
+// bumpPEP440Prerelease moves a version one step along
+// .devN < aN < bN < rcN < final: a dev release becomes the pre-release it
+// leads to, a pre-release counts up or advances to the identifier's phase,
+// and a final or post-release starts the next patch's pre-releases.
+func bumpPEP440Prerelease(v *pep440Version, identifier string) error {
+	tag, dev, err := pep440Phase(identifier)
+	if err != nil {
+		return err
+	}
+	switch {
+	case dev && v.hasDev:
+		v.dev = incNumStr(trimLeadingZeros(v.dev))
+	case dev && v.hasPre && !v.hasPost:
+		v.preNum = incNumStr(trimLeadingZeros(v.preNum))
+		v.hasDev, v.dev = true, "0"
+	case v.hasPost || (!v.hasPre && !v.hasDev):
+		v.release = bumpRelease(v.release, bumpIndex(BumpPatch), false)
+		clearPEP440Suffixes(v)
+		return startPEP440Prerelease(v, identifier)
+	case v.hasPre && (identifier == "" || tag == v.preTag):
+		if v.hasDev {
+			v.hasDev, v.dev = false, ""
+		} else {
+			v.preNum = incNumStr(trimLeadingZeros(v.preNum))
+		}
+	case v.hasPre:
+		v.preTag, v.preNum = tag, "1"
+		v.hasDev, v.dev = false, ""
+	default:
+		// A dev release of a final release, such as 1.0.dev3, leads to 1.0a1.
+		v.hasDev, v.dev = false, ""
+		v.hasPre, v.preTag, v.preNum = true, tag, "1"
+	}
+	return nil
+}
+
+func bumpMaven(version, scheme string, kind BumpKind, identifier string) (string, error) {
+	release, qualifier := splitNumericRelease(version)
+	qualifier = strings.TrimLeft(qualifier, ".-")
+	prerelease := qualifier != "" && hasMavenPrereleaseQualifier(qualifier)
+
+	switch kind {
+	case BumpMajor, BumpMinor, BumpPatch:
+		release = bumpRelease(release, bumpIndex(kind), prerelease)
+		qualifier = ""
+	case BumpPremajor, BumpPreminor, BumpPrepatch:
+		release = bumpRelease(release, bumpIndex(kind), false)
+		qualifier = mavenQualifier(identifier)
+	case BumpPrerelease:
+		switch {
+		case !prerelease:
+			release = bumpRelease(release, bumpIndex(BumpPatch), false)
+			qualifier = mavenQualifier(identifier)
+		case identifier != "" && !strings.EqualFold(prereleaseName(qualifier), identifier):
+			qualifier = mavenQualifier(identifier)
+		case strings.EqualFold(qualifier, "snapshot"):
+			return "", fmt.Errorf("cannot increment the SNAPSHOT qualifier of %s", version)
+		default:
+			next, ok := incTrailingNumber(qualifier)
+			if !ok {
+				next = qualifier + "-1"
+			}
+			qualifier = next
+		}
+	case BumpRelease:
+		if !prerelease {
+			return "", notPrerelease(version)
+		}
+		qualifier = ""
+	default:
+		return "", unsupportedBump(kind, scheme)
+	}
+
+	bumped := strings.Join(release, ".")
+	if qualifier != "" {
+		bumped += "-" + qualifier
+	}
+	return bumped, nil
+}
+
+// mavenQualifier returns the first qualifier for identifier: SNAPSHOT by
+// default, or identifier-1.
+func mavenQualifier(identifier string) string {
+	if identifier == "" || strings.EqualFold(identifier, "snapshot") {
+		return strings.ToUpper(cmp.Or(identifier, "SNAPSHOT"))
+	}
+	return identifier + "-1"
+}
+
+func bumpGem(version string, kind BumpKind, identifier string) (string, error) {
+	release, rest := splitNumericRelease(version)
+	rest = strings.TrimLeft(rest, ".-")
+	prerelease := rest != ""
+	first := cmp.Or(identifier, "pre") + "1"
+
+	switch kind {
+	case BumpMajor, BumpMinor, BumpPatch:
+		release = bumpRelease(release, bumpIndex(kind), prerelease)
+		rest = ""
+	case BumpPremajor, BumpPreminor, BumpPrepatch:
+		release = bumpRelease(release, bumpIndex(kind), false)
+		rest = first
+	case BumpPrerelease:
+		switch {
+		case !prerelease:
+			release = bumpRelease(release, bumpIndex(BumpPatch), false)
+			rest = first
+		case identifier != "" && !strings.EqualFold(prereleaseName(rest), identifier):
+			rest = first
+		default:
+			next, ok := incTrailingNumber(rest)
+			if !ok {
+				next = rest + "1"
+			}
+			rest = next
+		}
+	case BumpRelease:
+		if !prerelease {
+			return "", notPrerelease(version)
+		}
+		rest = ""
+	default:
+		return "", unsupportedBump(kind, schemeGem)
+	}
+
+	bumped := strings.Join(release, ".")
+	if rest != "" {
+		bumped += "." + rest
+	}
+	return bumped, nil
+}
+
+// bumpPackageVersion bumps [epoch:]upstream[-revision] versions for deb and
+// rpm. Changing the upstream version restarts the revision at 1.
+func bumpPackageVersion(version, scheme string, kind BumpKind, identifier string) (string, error) {
+	epoch, upstream := "", version
+	if i := strings.IndexByte(upstream, ':'); i >= 0 {
+		epoch, upstream = upstream[:i+1], upstream[i+1:]
+	}
+	revision, hasRevision := "", false
+	if i := strings.LastIndexByte(upstream, '-'); i >= 0 {
+		upstream, revision, hasRevision = upstream[:i], upstream[i+1:], true
+	}
+
+	if kind == BumpRevision {
+		switch {
+		case !hasRevision:
+			revision = "1"
+		case scheme == schemeRPM:
+			// RPM releases lead with the build number, as in 1.el9.
+			end := 0
+			for end < len(revision) && isASCIIDigit(revision[end]) {
+				end++
+			}
+			if end == 0 {
+				return "", fmt.Errorf("rpm release of %s does not start with a number", version)
+			}
+			revision = incNumStr(trimLeadingZeros(revision[:end])) + revision[end:]
+		default:
+			next, ok := incTrailingNumber(revision)
+			if !ok {
+				next = revision + "1"
+			}
+			revision = next
+		}
+		return epoch + upstream + "-" + revision, nil
+	}
+
+	bumpedUpstream, err := bumpUpstream(upstream, scheme, kind, identifier)
+	if err != nil {
+		return "", err
+	}
+	if hasRevision {
+		return epoch + bumpedUpstream + "-1", nil
+	}
+	return epoch + bumpedUpstream, nil
+}
+
+// bumpUpstream bumps a Debian upstream or RPM version, where a ~ suffix
+// marks a prerelease.
+func bumpUpstream(upstream, scheme string, kind BumpKind, identifier string) (string, error) {
+	release, rest := splitNumericRelease(upstream)
+	prerelease := strings.HasPrefix(rest, "~")
+	label := strings.TrimPrefix(rest, "~")
+	first := "~" + cmp.Or(identifier, "rc") + "1"
+
+	switch kind {
+	case BumpMajor, BumpMinor, BumpPatch:
+		return strings.Join(bumpRelease(release, bumpIndex(kind), prerelease), "."), nil
+	case BumpPremajor, BumpPreminor, BumpPrepatch:
+		return strings.Join(bumpRelease(release, bumpIndex(kind), false), ".") + first, nil
+	case BumpPrerelease:
+		switch {
+		case !prerelease:
+			return strings.Join(bumpRelease(release, bumpIndex(BumpPatch), false), ".") + first, nil
+		case identifier != "" && !strings.EqualFold(prereleaseName(label), identifier):
+			return strings.Join(release, ".") + first, nil
+		}
+		next, ok := incTrailingNumber(rest)
+		if !ok {
+			next = rest + "1"
+		}
+		return strings.Join(release, ".") + next, nil
+	case BumpRelease:
+		if !prerelease {
+			return "", notPrerelease(upstream)
+		}
+		return strings.Join(release, "."), nil
+	}
+	return "", unsupportedBump(kind, scheme)
+}
@@ -0,0 +1,144 @@
+package vers
+
+import "testing"
+
+func TestBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version, scheme string
+		kind            BumpKind
+		identifier      string
+		want            string
+	}{
+		// node-semver inc
+		{"1.2.3", "npm", BumpMajor, "", "2.0.0"},
+		{"1.2.3", "npm", BumpMinor, "", "1.3.0"},
+		{"1.2.3", "npm", BumpPatch, "", "1.2.4"},
+		{"2.0.0-rc.1", "npm", BumpMajor, "", "2.0.0"},
+		{"1.2.0-rc.1", "npm", BumpMajor, "", "2.0.0"},
+		{"1.3.0-rc.1", "npm", BumpMinor, "", "1.3.0"},
+		{"1.2.4-rc.1", "npm", BumpPatch, "", "1.2.4"},
+		{"1.2.3", "npm", BumpPremajor, "", "2.0.0-0"},
+		{"1.2.3", "npm", BumpPremajor, "beta", "2.0.0-beta.0"},
+		{"1.2.3", "npm", BumpPreminor, "", "1.3.0-0"},
+		{"1.2.3", "npm", BumpPrepatch, "alpha", "1.2.4-alpha.0"},
+		{"1.2.3", "npm", BumpPrerelease, "", "1.2.4-0"},
+		{"1.2.4-0", "npm", BumpPrerelease, "", "1.2.4-1"},
+		{"1.2.4-alpha.1", "npm", BumpPrerelease, "", "1.2.4-alpha.2"},
+		{"1.2.4-alpha", "npm", BumpPrerelease, "", "1.2.4-alpha.0"},
+		{"1.2.4-alpha.1", "npm", BumpPrerelease, "beta", "1.2.4-beta.0"},
+		{"1.2.4-beta.3", "npm", BumpRelease, "", "1.2.4"},
+		{"1.2.3+build", "semver", BumpPatch, "", "1.2.4"},
+		{"v1.2.3", "go", BumpMinor, "", "v1.3.0"},
+		{"v2.0.0+incompatible", "go", BumpMajor, "", "v3.0.0+incompatible"},
+		{"0.1.0", "cargo", BumpMinor, "", "0.2.0"},
+
+		// PEP 440
+		{"1.2", "pypi", BumpMajor, "", "2.0"},
+		{"1!1.2.3", "pypi", BumpMinor, "", "1!1.3.0"},
+		{"1.2", "pypi", BumpPatch, "", "1.2.1"},
+		{"2.0rc1", "pypi", BumpMajor, "", "2.0"},
+		{"1.0.post1.dev1", "pypi", BumpMajor, "", "2.0"},
+		{"1.0", "pypi", BumpPremajor, "", "2.0a1"},
+		{"1.0", "pypi", BumpPreminor, "rc", "1.1rc1"},
+		{"1.0", "pypi", BumpPrepatch, "dev", "1.0.1.dev0"},
+		{"1.0.dev3", "pypi", BumpPrerelease, "", "1.0a1"},
+		{"1.0.dev3", "pypi", BumpPrerelease, "dev", "1.0.dev4"},
+		{"1.0.dev3", "pypi", BumpPrerelease, "beta", "1.0b1"},
+		{"1.0a1", "pypi", BumpPrerelease, "", "1.0a2"},
+		{"1.0a2", "pypi", BumpPrerelease, "rc", "1.0rc1"},
+		{"1.0rc1.dev2", "pypi", BumpPrerelease, "", "1.0rc1"},
+		{"1.0rc1", "pypi", BumpPrerelease, "dev", "1.0rc2.dev0"},
+		{"1.0", "pypi", BumpPrerelease, "", "1.0.1a1"},
+		{"1.0.post2", "pypi", BumpPrerelease, "", "1.0.1a1"},
+		{"1.0rc1", "pypi", BumpRelease, "", "1.0"},
+		{"1.0.post1.dev1", "pypi", BumpRelease, "", "1.0.post1"},
+		{"1.0", "pypi", BumpPost, "", "1.0.post1"},
+		{"1.0.post1", "pypi", BumpPost, "", "1.0.post2"},
+		{"1.0.post1.dev1", "pypi", BumpPost, "", "1.0.post1"},
+		{"1.0+local", "pypi", BumpPatch, "", "1.0.1"},
+
+		// Maven
+		{"1.0", "maven", BumpMajor, "", "2.0"},
+		{"1.2.3", "maven", BumpMinor, "", "1.3.0"},
+		{"1.0", "maven", BumpPatch, "", "1.0.1"},
+		{"2.0-SNAPSHOT", "maven", BumpMajor, "", "2.0"},
+		{"1.0", "maven", BumpPrerelease, "", "1.0.1-SNAPSHOT"},
+		{"1.0", "maven", BumpPremajor, "alpha", "2.0-alpha-1"},
+		{"1.0-alpha-1", "maven", BumpPrerelease, "", "1.0-alpha-2"},
+		{"1.0-M1", "maven", BumpPrerelease, "", "1.0-M2"},
+		{"1.0-beta", "maven", BumpPrerelease, "", "1.0-beta-1"},
+		{"1.0-alpha-2", "maven", BumpPrerelease, "beta", "1.0-beta-1"},
+		{"1.0-RC1", "gradle", BumpRelease, "", "1.0"},
+
+		// RubyGems
+		{"1.2.3", "gem", BumpMajor, "", "2.0.0"},
+		{"1.2", "gem", BumpPatch, "", "1.2.1"},
+		{"1.2.3", "gem", BumpPrerelease, "", "1.2.4.pre1"},
+		{"1.2.3", "gem", BumpPreminor, "rc", "1.3.0.rc1"},
+		{"2.0.0.rc1", "gem", BumpPrerelease, "", "2.0.0.rc2"},
+		{"2.0.0.beta", "gem", BumpPrerelease, "", "2.0.0.beta1"},
+		{"2.0.0.beta2", "gem", BumpPrerelease, "rc", "2.0.0.rc1"},
+		{"2.0.0.rc2", "gem", BumpRelease, "", "2.0.0"},
+
+		// Debian and RPM
+		{"1.2.3-1", "deb", BumpRevision, "", "1.2.3-2"},
+		{"1.2.3-1ubuntu1", "deb", BumpRevision, "", "1.2.3-1ubuntu2"},
+		{"1.2.3", "deb", BumpRevision, "", "1.2.3-1"},
+		{"2:1.2.3-4", "debian", BumpMinor, "", "2:1.3.0-1"},
+		{"1.2.3+dfsg-2", "deb", BumpPatch, "", "1.2.4-1"},
+		{"1.2.3-1", "deb", BumpPrerelease, "", "1.2.4~rc1-1"},
+		{"1.2.4~rc1-1", "deb", BumpPrerelease, "", "1.2.4~rc2-1"},
+		{"1.2.4~rc2-3", "deb", BumpRelease, "", "1.2.4-1"},
+		{"1.2.3-1.el9", "rpm", BumpRevision, "", "1.2.3-2.el9"},
+		{"1:1.2.3-4.el9", "rpm", BumpMajor, "", "1:2.0.0-1"},
+	}
+	for _, tt := range tests {
+		var opts []BumpOption
+		if tt.identifier != "" {
+			opts = append(opts, PrereleaseIdentifier(tt.identifier))
+		}
+		got, err := Bump(tt.version, tt.scheme, tt.kind, opts...)
+		if err != nil {
+			t.Errorf("Bump(%q, %s, %s, %q) error: %v", tt.version, tt.scheme, tt.kind, tt.identifier, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Bump(%q, %s, %s, %q) = %q, want %q", tt.version, tt.scheme, tt.kind, tt.identifier, got, tt.want)
+		}
+		if CompareWithScheme(got, tt.version, tt.scheme) <= 0 {
+			t.Errorf("Bump(%q, %s, %s) = %q does not sort after the input", tt.version, tt.scheme, tt.kind, got)
+		}
+	}
+}
+
+func TestBumpErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version, scheme string
+		kind            BumpKind
+		identifier      string
+	}{
+		{"not a version", "npm", BumpPatch, ""},
+		{"1.2.3", "npm", BumpRelease, ""},
+		{"1.2.3", "npm", BumpPost, ""},
+		{"1.0rc1", "pypi", BumpPrerelease, "alpha"},
+		{"1.0", "pypi", BumpPrerelease, "gamma"},
+		{"1.0", "pypi", BumpRevision, ""},
+		{"1.0-SNAPSHOT", "maven", BumpPrerelease, ""},
+		{"1.0-rc-1", "maven", BumpPrerelease, "alpha"},
+		{"1.0-abc", "rpm", BumpRevision, ""},
+		{"1.0", "conan", BumpPatch, ""},
+	}
+	for _, tt := range tests {
+		var opts []BumpOption
+		if tt.identifier != "" {
+			opts = append(opts, PrereleaseIdentifier(tt.identifier))
+		}
+		if got, err := Bump(tt.version, tt.scheme, tt.kind, opts...); err == nil {
+			t.Errorf("Bump(%q, %s, %s, %q) = %q, expected error", tt.version, tt.scheme, tt.kind, tt.identifier, got)
+		}
+	}
+}
@@ -0,0 +1,543 @@
+package vers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CargoOp is the operator of a Cargo version requirement comparator.
+type CargoOp int
+
+const (
+	// CargoOpCaret is ^, also used when a comparator has no operator.
+	CargoOpCaret CargoOp = iota
+	// CargoOpExact is =.
+	CargoOpExact
+	// CargoOpGreater is >.
+	CargoOpGreater
+	// CargoOpGreaterEq is >=.
+	CargoOpGreaterEq
+	// CargoOpLess is <.
+	CargoOpLess
+	// CargoOpLessEq is <=.
+	CargoOpLessEq
+	// CargoOpTilde is ~.
+	CargoOpTilde
+	// CargoOpWildcard is a comparator without an operator and with a * in
+	// place of the minor or patch number, such as 1.*.
+	CargoOpWildcard
+)
+
+// String returns the operator as written in a requirement. The wildcard
+// operator is written as the empty string.
+func (o CargoOp) String() string {
+	switch o {
+	case CargoOpCaret:
+		return "^"
+	case CargoOpExact:
+		return "="
+	case CargoOpGreater:
+		return ">"
+	case CargoOpGreaterEq:
+		return ">="
+	case CargoOpLess:
+		return "<"
+	case CargoOpLessEq:
+		return "<="
+	case CargoOpTilde:
+		return "~"
+	}
+	return ""
+}
+
+// CargoComparator is one comparator of a Cargo version requirement. Minor
+// and Patch are nil when the comparator leaves them out.
+type CargoComparator struct {
+	Op    CargoOp
+	Major uint64
+	Minor *uint64
+	Patch *uint64
+	Pre   string
+}
+
+// CargoVersionReq is a Cargo dependency version requirement, parsed the way
+// the semver crate's VersionReq parses it. No comparators means *.
+type CargoVersionReq struct {
+	Comparators []CargoComparator
+}
+
+// maxCargoComparators is the semver crate's limit on comparators per requirement.
+const maxCargoComparators = 32
+
+// ParseCargoVersionReq parses a Cargo.toml version requirement such as
+// "1.2.3", "^1.2, <1.5" or "1.*". It follows the semver crate: comparators
+// are separated by commas, a bare version is a caret requirement, * and x
+// only stand in for whole numbers, and npm syntax such as ||, hyphen ranges
+// and space-separated comparators is rejected.
+func ParseCargoVersionReq(requirement string) (*CargoVersionReq, error) {
+	text := strings.TrimLeft(requirement, " ")
+	if rest, ok := cutCargoWildcard(text); ok {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return &CargoVersionReq{}, nil
+		}
+		if strings.HasPrefix(rest, ",") {
+			return nil, cargoReqError(requirement, "wildcard %q must be the only comparator", text[:1])
+		}
+		return nil, cargoReqError(requirement, "unexpected character after wildcard in version requirement")
+	}
+
+	req := &CargoVersionReq{}
+	for {
+		comparator, rest, err := parseCargoComparator(text)
+		if err != nil {
+			return nil, cargoReqError(requirement, "%s", err)
+		}
+		req.Comparators = append(req.Comparators, comparator)
+		if rest == "" {
+			return req, nil
+		}
+		if rest[0] != ',' {
+			return nil, cargoReqError(requirement, "expected comma after comparator, found %q", rest[:1])
+		}
+		if len(req.Comparators) == maxCargoComparators {
+			return nil, cargoReqError(requirement, "more than %d comparators", maxCargoComparators)
+		}
+		text = strings.TrimLeft(rest[1:], " ")
+	}
+}
+
+func cargoReqError(requirement, format string, args ...any) error {
+	return fmt.Errorf("invalid cargo version requirement %q: %s", requirement, fmt.Sprintf(format, args...))
+}
+
+func cutCargoWildcard(text string) (string, bool) {
+	if text != "" && (text[0] == '*' || text[0] == 'x' || text[0] == 'X') {
+		return text[1:], true
+	}
+	return text, false
+}
+
+func cutCargoOp(text string) (CargoOp, string, bool) {
+	switch {
+	case strings.HasPrefix(text, ">="):
+		return CargoOpGreaterEq, text[2:], true
+	case strings.HasPrefix(text, "<="):
+		return CargoOpLessEq, text[2:], true
+	case strings.HasPrefix(text, "="):
+		return CargoOpExact, text[1:], true
+	case strings.HasPrefix(text, ">"):
+		return CargoOpGreater, text[1:], true
+	case strings.HasPrefix(text, "<"):
+		return CargoOpLess, text[1:], true
+	case strings.HasPrefix(text, "~"):
+		return CargoOpTilde, text[1:], true
+	case strings.HasPrefix(text, "^"):
+		return CargoOpCaret, text[1:], true
+	}
+	return CargoOpCaret, text, false
+}
+
+func parseCargoComparator(input string) (CargoComparator, string, error) {
+	op, text, explicit := cutCargoOp(input)
+	text = strings.TrimLeft(text, " ")
+	comparator := CargoComparator{Op: op}
+
+	var err error
+	if comparator.Major, text, err = cutCargoNumber(text, "major"); err != nil {
+		return comparator, "", err
+	}
+	wildcard := false
+	if rest, ok := strings.CutPrefix(text, "."); ok {
+		if rest, ok = cutCargoWildcard(rest); ok {
+			wildcard = true
+			if !explicit {
+				comparator.Op = CargoOpWildcard
+			}
+			text = rest
+		} else {
+			var minor uint64
+			if minor, text, err = cutCargoNumber(rest, "minor"); err != nil {
+				return comparator, "", err
+			}
+			comparator.Minor = &minor
+		}
+	}
+	if rest, ok := strings.CutPrefix(text, "."); ok {
+		if rest, ok = cutCargoWildcard(rest); ok {
+			if !explicit {
+				comparator.Op = CargoOpWildcard
+			}
+			text = rest
+		} else if wildcard {
+			return comparator, "", fmt.Errorf("unexpected number after wildcard")
+		} else {
+			var patch uint64
+			if patch, text, err = cutCargoNumber(rest, "patch"); err != nil {
+				return comparator, "", err
+			}
+			comparator.Patch = &patch
+		}
+	}
+
+	if comparator.Patch != nil {
+		if rest, ok := strings.CutPrefix(text, "-"); ok {
+			if comparator.Pre, text, err = cutCargoIdentifier(rest, "pre-release"); err != nil {
+				return comparator, "", err
+			}
+		}
+		if rest, ok := strings.CutPrefix(text, "+"); ok {
+			// Build metadata is allowed but plays no part in matching.
+			if _, text, err = cutCargoIdentifier(rest, "build metadata"); err != nil {
+				return comparator, "", err
+			}
+		}
+	}
+	return comparator, strings.TrimLeft(text, " "), nil
+}
+
+// cutCargoNumber reads a number without leading zeros.
+func cutCargoNumber(text, position string) (uint64, string, error) {
+	end := 0
+	for end < len(text) && isASCIIDigit(text[end]) {
+		end++
+	}
+	switch {
+	case end == 0 && text == "":
+		return 0, "", fmt.Errorf("unexpected end of input while parsing %s version number", position)
+	case end == 0:
+		return 0, "", fmt.Errorf("unexpected character %q while parsing %s version number", text[:1], position)
+	case end > 1 && text[0] == '0':
+		return 0, "", fmt.Errorf("invalid leading zero in %s version number", position)
+	}
+	value, err := strconv.ParseUint(text[:end], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("value of %s version number exceeds u64::MAX", position)
+	}
+	return value, text[end:], nil
+}
+
+// cutCargoIdentifier reads dot-separated pre-release or build identifiers.
+func cutCargoIdentifier(text, position string) (string, string, error) {
+	end := 0
+	for end < len(text) && (isASCIIAlnum(text[end]) || text[end] == '-' || text[end] == '.') {
+		end++
+	}
+	identifier := text[:end]
+	for _, segment := range strings.Split(identifier, ".") {
+		if segment == "" {
+			return "", "", fmt.Errorf("empty identifier segment in %s", position)
+		}
+		if position == "pre-release" && len(segment) > 1 && segment[0] == '0' && isDigits(segment) {
+			return "", "", fmt.Errorf("invalid leading zero in %s identifier", position)
+		}
+	}
+	return identifier, text[end:], nil
+}
+
+// String formats the comparator like the semver crate's Display.
+func (c CargoComparator) String() string {
+	var b strings.Builder
+	b.WriteString(c.Op.String())
+	b.WriteString(strconv.FormatUint(c.Major, 10))
+	switch {
+	case c.Minor == nil:
+		if c.Op == CargoOpWildcard {
+			b.WriteString(".*")
+		}
+	case c.Patch == nil:
+		fmt.Fprintf(&b, ".%d", *c.Minor)
+		if c.Op == CargoOpWildcard {
+			b.WriteString(".*")
+		}
+	default:
+		fmt.Fprintf(&b, ".%d.%d", *c.Minor, *c.Patch)
+		if c.Pre != "" {
+			b.WriteString("-" + c.Pre)
+		}
+	}
+	return b.String()
+}
+
+// String formats the requirement like the semver crate's Display: * for no
+// comparators, and comparators joined by ", " otherwise. A bare version is
+// written with its implied ^.
+func (r *CargoVersionReq) String() string {
+	if len(r.Comparators) == 0 {
+		return "*"
+	}
+	parts := make([]string, len(r.Comparators))
+	for i, comparator := range r.Comparators {
+		parts[i] = comparator.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cargoVersion is a version as the semver crate parses it.
+type cargoVersion struct {
+	major, minor, patch uint64
+	pre                 string
+}
+
+func parseCargoVersion(version string) (cargoVersion, bool) {
+	var v cargoVersion
+	var err error
+	text := version
+	for i, position := range []string{"major", "minor", "patch"} {
+		if i > 0 {
+			var ok bool
+			if text, ok = strings.CutPrefix(text, "."); !ok {
+				return v, false
+			}
+		}
+		var number uint64
+		if number, text, err = cutCargoNumber(text, position); err != nil {
+			return v, false
+		}
+		switch i {
+		case 0:
+			v.major = number
+		case 1:
+			v.minor = number
+		default:
+			v.patch = number
+		}
+	}
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		if v.pre, text, err = cutCargoIdentifier(rest, "pre-release"); err != nil {
+			return v, false
+		}
+	}
+	if rest, ok := strings.CutPrefix(text, "+"); ok {
+		if _, text, err = cutCargoIdentifier(rest, "build metadata"); err != nil {
+			return v, false
+		}
+	}
+	return v, text == ""
+}
+
+// Matches reports whether version satisfies the requirement with the semver
+// crate's rules. Every comparator must match, and a prerelease only matches
+// when some comparator names the same major.minor.patch with a prerelease.
+// Invalid versions never match.
+func (r *CargoVersionReq) Matches(version string) bool {
+	v, ok := parseCargoVersion(version)
+	if !ok {
+		return false
+	}
+	for _, comparator := range r.Comparators {
+		if !comparator.matches(v) {
+			return false
+		}
+	}
+	if v.pre == "" {
+		return true
+	}
+	for _, c := range r.Comparators {
+		if c.Major == v.major && c.Minor != nil && *c.Minor == v.minor &&
+			c.Patch != nil && *c.Patch == v.patch && c.Pre != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CargoComparator) matches(v cargoVersion) bool {
+	switch c.Op {
+	case CargoOpExact, CargoOpWildcard:
+		return c.matchesExact(v)
+	case CargoOpGreater:
+		return c.matchesGreater(v)
+	case CargoOpGreaterEq:
+		return c.matchesExact(v) || c.matchesGreater(v)
+	case CargoOpLess:
+		return c.matchesLess(v)
+	case CargoOpLessEq:
+		return c.matchesExact(v) || c.matchesLess(v)
+	case CargoOpTilde:
+		return c.matchesTilde(v)
+	}
+	return c.matchesCaret(v)
+}
+
+func (c CargoComparator) comparePre(v cargoVersion) int {
+	return compareSemverPrereleaseStrings(v.pre, c.Pre)
+}
+
+func (c CargoComparator) matchesExact(v cargoVersion) bool {
+	if v.major != c.Major || c.Minor != nil && v.minor != *c.Minor || c.Patch != nil && v.patch != *c.Patch {
+		return false
+	}
+	return v.pre == c.Pre
+}
+
+func (c CargoComparator) matchesGreater(v cargoVersion) bool {
+	if v.major != c.Major {
+		return v.major > c.Major
+	}
+	if c.Minor == nil {
+		return false
+	}
+	if v.minor != *c.Minor {
+		return v.minor > *c.Minor
+	}
+	if c.Patch == nil {
+		return false
+	}
+	if v.patch != *c.Patch {
+		return v.patch > *c.Patch
+	}
+	return c.comparePre(v) > 0
+}
+
+func (c CargoComparator) matchesLess(v cargoVersion) bool {
+	if v.major != c.Major {
+		return v.major < c.Major
+	}
+	if c.Minor == nil {
+		return false
+	}
+	if v.minor != *c.Minor {
+		return v.minor < *c.Minor
+	}
+	if c.Patch == nil {
+		return false
+	}
+	if v.patch != *c.Patch {
+		return v.patch < *c.Patch
+	}
+	return c.comparePre(v) < 0
+}
+
+func (c CargoComparator) matchesTilde(v cargoVersion) bool {
+	if v.major != c.Major || c.Minor != nil && v.minor != *c.Minor {
+		return false
+	}
+	if c.Patch != nil && v.patch != *c.Patch {
+		return v.patch > *c.Patch
+	}
+	return c.comparePre(v) >= 0
+}
+
+func (c CargoComparator) matchesCaret(v cargoVersion) bool {
+	if v.major != c.Major {
+		return false
+	}
+	if c.Minor == nil {
+		return true
+	}
+	minor := *c.Minor
+	if c.Patch == nil {
+		if c.Major > 0 {
+			return v.minor >= minor
+		}
+		return v.minor == minor
+	}
+	patch := *c.Patch
+	switch {
+	case c.Major > 0:
+		if v.minor != minor {
+			return v.minor > minor
+		}
+		if v.patch != patch {
+			return v.patch > patch
+		}
+	case minor > 0:
+		if v.minor != minor {
+			return false
+		}
+		if v.patch != patch {
+			return v.patch > patch
+		}
+	case v.minor != minor || v.patch != patch:
+		return false
+	}
+	return c.comparePre(v) >= 0
+}
+
+// Range returns the requirement as a cargo Range. Exclusive upper bounds
+// use the -0 prerelease so that prereleases of the next release stay out.
+// Contains approximates the crate's per-comparator prerelease rule with the
+// interval bounds; use Matches where exact crate behavior matters.
+func (r *CargoVersionReq) Range() *Range {
+	result := Unbounded()
+	for i, comparator := range r.Comparators {
+		next := NewRange([]Interval{comparator.interval()})
+		if i == 0 {
+			result = next
+		} else {
+			result = result.Intersect(next)
+		}
+	}
+	result.Scheme = schemeCargo
+	return result
+}
+
+// interval returns the versions a comparator admits as one interval.
+func (c CargoComparator) interval() Interval {
+	major := strconv.FormatUint(c.Major, 10)
+	nextMajor := incNumStr(major) + ".0.0-0"
+	if c.Minor == nil {
+		lower := major + ".0.0"
+		switch c.Op {
+		case CargoOpGreater:
+			return NewInterval(incNumStr(major)+".0.0", "", true, false)
+		case CargoOpGreaterEq:
+			return NewInterval(lower, "", true, false)
+		case CargoOpLess:
+			return NewInterval("", lower+"-0", false, false)
+		case CargoOpLessEq:
+			return NewInterval("", nextMajor, false, false)
+		}
+		return NewInterval(lower, nextMajor, true, false)
+	}
+
+	minor := strconv.FormatUint(*c.Minor, 10)
+	nextMinor := major + "." + incNumStr(minor) + ".0-0"
+	if c.Patch == nil {
+		lower := major + "." + minor + ".0"
+		switch c.Op {
+		case CargoOpGreater:
+			return NewInterval(major+"."+incNumStr(minor)+".0", "", true, false)
+		case CargoOpGreaterEq:
+			return NewInterval(lower, "", true, false)
+		case CargoOpLess:
+			return NewInterval("", lower+"-0", false, false)
+		case CargoOpLessEq:
+			return NewInterval("", nextMinor, false, false)
+		case CargoOpCaret:
+			if c.Major > 0 {
+				return NewInterval(lower, nextMajor, true, false)
+			}
+		}
+		return NewInterval(lower, nextMinor, true, false)
+	}
+
+	patch := strconv.FormatUint(*c.Patch, 10)
+	version := major + "." + minor + "." + patch
+	if c.Pre != "" {
+		version += "-" + c.Pre
+	}
+	switch c.Op {
+	case CargoOpGreater:
+		return NewInterval(version, "", false, false)
+	case CargoOpGreaterEq:
+		return NewInterval(version, "", true, false)
+	case CargoOpLess:
+		return NewInterval("", version, false, false)
+	case CargoOpLessEq:
+		return NewInterval("", version, false, true)
+	case CargoOpTilde:
+		return NewInterval(version, nextMinor, true, false)
+	case CargoOpCaret:
+		switch {
+		case c.Major > 0:
+			return NewInterval(version, nextMajor, true, false)
+		case *c.Minor > 0:
+			return NewInterval(version, nextMinor, true, false)
+		}
+		return NewInterval(version, major+"."+minor+"."+incNumStr(patch)+"-0", true, false)
+	}
+	return ExactInterval(version)
+}
@@ -0,0 +1,166 @@
+package vers
+
+import "testing"
+
+func TestParseCargoVersionReqDisplay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1.0.0", "^1.0.0"},
+		{"  1.2", "^1.2"},
+		{"^1", "^1"},
+		{">= 1.0.0", ">=1.0.0"},
+		{">=1.0.0, <2.0.0", ">=1.0.0, <2.0.0"},
+		{">=1.0.0 ,<2.0.0  ", ">=1.0.0, <2.0.0"},
+		{"=1.2.3-alpha.1+build.5", "=1.2.3-alpha.1"},
+		{"~1.2", "~1.2"},
+		{"*", "*"},
+		{"x", "*"},
+		{"1.*", "1.*"},
+		{"1.X", "1.*"},
+		{"1.*.*", "1.*"},
+		{"1.2.*", "1.2.*"},
+		{">=1.*", ">=1"},
+		{"<=1.2.x", "<=1.2"},
+		{"0.0.0-0", "^0.0.0-0"},
+	}
+	for _, tt := range tests {
+		req, err := ParseCargoVersionReq(tt.input)
+		if err != nil {
+			t.Errorf("ParseCargoVersionReq(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := req.String(); got != tt.want {
+			t.Errorf("ParseCargoVersionReq(%q).String() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseCargoVersionReqErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"",
+		">= >= 0.0.2",
+		">= 0.0.2 || 1",
+		"1.0.0 - 2.0.0",
+		">=1.0.0 <2.0.0",
+		"*, >=1.0",
+		"* 1",
+		"1.*.3",
+		"01.0.0",
+		"1.2.3-01",
+		"1.2.3-",
+		"1.2.3-a..b",
+		"v1.2.3",
+		"1.0.0,",
+		"18446744073709551616",
+		"1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, " +
+			"1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, " +
+			"1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0, 1.0.0",
+	}
+	for _, input := range tests {
+		if req, err := ParseCargoVersionReq(input); err == nil {
+			t.Errorf("ParseCargoVersionReq(%q) = %v, expected error", input, req)
+		}
+		if _, err := ParseNative(input, "cargo"); err == nil {
+			t.Errorf("ParseNative(%q, cargo) expected error", input)
+		}
+	}
+}
+
+func TestCargoVersionReqMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		req     string
+		matches []string
+		rejects []string
+	}{
+		{"1.0.0", []string{"1.0.0", "1.0.1", "1.9.0"}, []string{"0.9.0", "2.0.0", "1.0.1-pre"}},
+		{"^1.0.0-alpha", []string{"1.0.0-alpha", "1.0.0-beta", "1.0.0", "1.5.0"}, []string{"1.5.0-alpha", "0.9.0"}},
+		{
+			">=0.5.1-alpha3, <0.6",
+			[]string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"},
+			[]string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.6.0", "0.6.0-pre"},
+		},
+		{"^0.0.1", []string{"0.0.1"}, []string{"0.0.2", "0.1.0"}},
+		{"^0.1", []string{"0.1.0", "0.1.5"}, []string{"0.2.0", "0.0.9"}},
+		{"^0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0"}},
+		{"~1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"1.2.*", []string{"1.2.0", "1.2.7"}, []string{"1.3.0", "1.2.0-pre"}},
+		{"=1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{">1", []string{"2.0.0"}, []string{"1.9.9", "2.0.0-pre"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<1.0", []string{"0.9.9"}, []string{"1.0.0", "1.0.0-beta"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"<1.2.3-beta", []string{"1.2.3-alpha", "1.2.2"}, []string{"1.2.3-beta", "1.2.3"}},
+		{"=0.1.0+meta", []string{"0.1.0", "0.1.0+other"}, []string{"0.1.1"}},
+		{"*", []string{"0.0.0", "9.9.9"}, []string{"1.0.0-pre", "not.a.version"}},
+	}
+	for _, tt := range tests {
+		req, err := ParseCargoVersionReq(tt.req)
+		if err != nil {
+			t.Fatalf("ParseCargoVersionReq(%q) error: %v", tt.req, err)
+		}
+		r := req.Range()
+		for _, version := range tt.matches {
+			if !req.Matches(version) {
+				t.Errorf("%q.Matches(%q) = false, want true", tt.req, version)
+			}
+			if !r.Contains(version) {
+				t.Errorf("%q.Range().Contains(%q) = false, want true", tt.req, version)
+			}
+		}
+		for _, version := range tt.rejects {
+			if req.Matches(version) {
+				t.Errorf("%q.Matches(%q) = true, want false", tt.req, version)
+			}
+			if r.Contains(version) {
+				t.Errorf("%q.Range().Contains(%q) = true, want false", tt.req, version)
+			}
+		}
+	}
+}
+
+func TestCargoVersionReqMatchesRejectsPartialVersions(t *testing.T) {
+	t.Parallel()
+
+	req, err := ParseCargoVersionReq("*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, version := range []string{"1.0", "v1.0.0", "01.0.0", "1.0.0-01"} {
+		if req.Matches(version) {
+			t.Errorf("Matches(%q) = true, want false", version)
+		}
+	}
+}
+
+func TestCargoVersionReqRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		req  string
+		want string
+	}{
+		{"1.2.3", "vers:cargo/>=1.2.3|<2.0.0-0"},
+		{"^0.2.3", "vers:cargo/>=0.2.3|<0.3.0-0"},
+		{"~1.2", "vers:cargo/>=1.2.0|<1.3.0-0"},
+		{"=1.2.3", "vers:cargo/1.2.3"},
+		{"*", "vers:cargo/*"},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.req, "cargo")
+		if err != nil {
+			t.Fatalf("ParseNative(%q, cargo) error: %v", tt.req, err)
+		}
+		if got := ToVersString(r, "cargo"); got != tt.want {
+			t.Errorf("ParseNative(%q, cargo) = %q, want %q", tt.req, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Command vers exposes the vers library to shell scripts and CI jobs.
+//
+//	vers satisfies <version> <constraint> [--scheme npm]
+//	vers compare <a> <b> [--scheme deb]
+//	vers sort [--scheme maven] [--reverse] [versions...] (or one per line on stdin)
+//	vers convert <constraint> --from npm [--to vers]
+//	vers normalize <version> [--scheme pypi]
+//	vers max-satisfying <constraint> [--scheme npm] [versions...] (or stdin)
+//	vers validate <version> [--scheme gem] [--constraint]
+//
+// Constraints that start with vers: are read as vers URIs; anything else
+// is native syntax for --scheme. Every command accepts --json.
+//
+// Exit code 2 always means a usage error or a constraint that does not
+// parse. Exit code 1 is the negative answer of each command:
+//
+//	satisfies       the version does not satisfy the constraint, including
+//	                a version that is not valid for the scheme
+//	max-satisfying  no version satisfies the constraint
+//	validate        the version, or with --constraint the constraint, is
+//	                not valid
+//
+// compare, sort, convert and normalize exit 0 on success and never exit 1.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	vers "github.com/git-pkgs/vers"
+)
+
+const (
+	exitOK       = 0
+	exitNegative = 1
+	exitUsage    = 2
+	versPrefix   = "vers:"
+	targetVers   = "vers"
+)
+
+const usage = `usage: vers <command> [arguments] [flags]
+
+commands:
+  satisfies <version> <constraint>   check whether a version satisfies a constraint
+  compare <a> <b>                    print -1, 0 or 1
+  sort [versions...]                 sort versions (stdin when none are given)
+  convert <constraint>               convert native syntax to a vers URI
+  normalize <version>                print the normalized version
+  max-satisfying <constraint> [versions...]
+                                     print the highest satisfying version
+  validate <version>                 check that a version (or --constraint) is valid
+
+flags:
+  --scheme string   version scheme, e.g. npm, pypi, maven
+  --json            print JSON instead of text
+`
+
+// errUsage marks errors caused by bad arguments rather than bad input data.
+var errUsage = errors.New("usage")
+
+type command struct {
+	name    string
+	flags   *flag.FlagSet
+	scheme  *string
+	json    *bool
+	stdin   io.Reader
+	stdout  io.Writer
+	args    []string
+	reverse *bool
+	from    *string
+	to      *string
+	isRange *bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	cmd := newCommand(args[0], stdin, stdout, stderr)
+	handlers := map[string]func(*command) (int, error){
+		"satisfies":      runSatisfies,
+		"compare":        runCompare,
+		"sort":           runSort,
+		"convert":        runConvert,
+		"normalize":      runNormalize,
+		"max-satisfying": runMaxSatisfying,
+		"validate":       runValidate,
+	}
+	handler, ok := handlers[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "vers: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	positional, err := parseInterspersed(cmd.flags, args[1:])
+	if err != nil {
+		return exitUsage
+	}
+	cmd.args = positional
+
+	code, err := handler(cmd)
+	if err != nil {
+		fmt.Fprintf(stderr, "vers %s: %v\n", cmd.name, err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(stderr, usage)
+		}
+		return exitUsage
+	}
+	return code
+}
+
+func newCommand(name string, stdin io.Reader, stdout, stderr io.Writer) *command {
+	flags := flag.NewFlagSet("vers "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	cmd := &command{
+		name:   name,
+		flags:  flags,
+		scheme: flags.String("scheme", "", "version scheme, e.g. npm, pypi, maven"),
+		json:   flags.Bool("json", false, "print JSON instead of text"),
+		stdin:  stdin,
+		stdout: stdout,
+	}
+	switch name {
+	case "sort":
+		cmd.reverse = flags.Bool("reverse", false, "sort from highest to lowest")
+	case "convert":
+		cmd.from = flags.String("from", "", "scheme of the input constraint, or vers")
+		cmd.to = flags.String("to", targetVers, "output format")
+	case "validate":
+		cmd.isRange = flags.Bool("constraint", false, "validate a constraint instead of a version")
+	}
+	return cmd
+}
+
+// parseInterspersed parses flags that appear before, between or after
+// positional arguments. Everything after -- is positional.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := flags.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func (c *command) expectArgs(count int, names string) error {
+	if len(c.args) != count {
+		return fmt.Errorf("%w: expected %s", errUsage, names)
+	}
+	return nil
+}
+
+func (c *command) output(value any, text string) error {
+	if *c.json {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(value)
+	}
+	_, err := fmt.Fprintln(c.stdout, text)
+	return err
+}
+
+// parseConstraint reads a vers URI, or native syntax for the command's scheme.
+func (c *command) parseConstraint(constraint string) (*vers.Range, error) {
+	if strings.HasPrefix(constraint, versPrefix) {
+		return vers.Parse(constraint)
+	}
+	if *c.scheme == "" {
+		return nil, fmt.Errorf("%w: --scheme is required for native constraint %q", errUsage, constraint)
+	}
+	return vers.ParseNative(constraint, *c.scheme)
+}
+
+// constraintScheme returns the scheme a constraint was read under: the
+// URI's own scheme for a vers URI, and --scheme for native syntax.
+func (c *command) constraintScheme(constraint string, r *vers.Range) string {
+	if strings.HasPrefix(constraint, versPrefix) {
+		return r.Scheme
+	}
+	return *c.scheme
+}
+
+// versions returns the positional versions, or the non-blank lines of stdin
+// when there are none.
+func (c *command) versions(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var versions []string
+	scanner := bufio.NewScanner(c.stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			versions = append(versions, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read versions: %w", err)
+	}
+	return versions, nil
+}
+
+func exitFor(ok bool) int {
+	if ok {
+		return exitOK
+	}
+	return exitNegative
+}
+
+func runSatisfies(c *command) (int, error) {
+	if err := c.expectArgs(2, "<version> <constraint>"); err != nil { //nolint:mnd
+		return 0, err
+	}
+	version, constraint := c.args[0], c.args[1]
+	r, err := c.parseConstraint(constraint)
+	if err != nil {
+		return 0, err
+	}
+	satisfies := r.Contains(version)
+	result := struct {
+		Version    string `json:"version"`
+		Constraint string `json:"constraint"`
+		Scheme     string `json:"scheme,omitempty"`
+		Satisfies  bool   `json:"satisfies"`
+	}{version, constraint, c.constraintScheme(constraint, r), satisfies}
+	return exitFor(satisfies), c.output(result, fmt.Sprint(satisfies))
+}
+
+func runCompare(c *command) (int, error) {
+	if err := c.expectArgs(2, "<a> <b>"); err != nil { //nolint:mnd
+		return 0, err
+	}
+	comparison := vers.CompareWithScheme(c.args[0], c.args[1], *c.scheme)
+	result := struct {
+		A      string `json:"a"`
+		B      string `json:"b"`
+		Scheme string `json:"scheme,omitempty"`
+		Result int    `json:"result"`
+	}{c.args[0], c.args[1], *c.scheme, comparison}
+	return exitOK, c.output(result, fmt.Sprint(comparison))
+}
+
+func runSort(c *command) (int, error) {
+	versions, err := c.versions(c.args)
+	if err != nil {
+		return 0, err
+	}
+	sorted := slices.Clone(versions)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		if *c.reverse {
+			return vers.CompareWithScheme(b, a, *c.scheme)
+		}
+		return vers.CompareWithScheme(a, b, *c.scheme)
+	})
+	result := struct {
+		Versions []string `json:"versions"`
+	}{sorted}
+	if result.Versions == nil {
+		result.Versions = []string{}
+	}
+	return exitOK, c.output(result, strings.Join(sorted, "\n"))
+}
+
+func runConvert(c *command) (int, error) {
+	if err := c.expectArgs(1, "<constraint>"); err != nil {
+		return 0, err
+	}
+	if *c.to != targetVers {
+		return 0, fmt.Errorf("%w: unsupported --to %q; only vers is supported", errUsage, *c.to)
+	}
+	from := *c.from
+	if from == "" {
+		from = *c.scheme
+	}
+	constraint := c.args[0]
+
+	var r *vers.Range
+	var err error
+	scheme := from
+	if from == "" || from == targetVers {
+		r, err = vers.Parse(constraint)
+		if r != nil {
+			scheme = r.Scheme
+		}
+	} else {
+		r, err = vers.ParseNative(constraint, from)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	converted := vers.ToVersString(r, scheme)
+	result := struct {
+		Input  string `json:"input"`
+		From   string `json:"from"`
+		Scheme string `json:"scheme,omitempty"`
+		To     string `json:"to"`
+		Output string `json:"output"`
+	}{constraint, from, scheme, *c.to, converted}
+	if result.From == "" {
+		result.From = targetVers
+	}
+	return exitOK, c.output(result, converted)
+}
+
+func runNormalize(c *command) (int, error) {
+	if err := c.expectArgs(1, "<version>"); err != nil {
+		return 0, err
+	}
+	normalized, err := vers.NormalizeWithScheme(c.args[0], *c.scheme)
+	if err != nil {
+		return 0, err
+	}
+	result := struct {
+		Version    string `json:"version"`
+		Scheme     string `json:"scheme,omitempty"`
+		Normalized string `json:"normalized"`
+	}{c.args[0], *c.scheme, normalized}
+	return exitOK, c.output(result, normalized)
+}
+
+func runMaxSatisfying(c *command) (int, error) {
+	if len(c.args) == 0 {
+		return 0, fmt.Errorf("%w: expected <constraint> [versions...]", errUsage)
+	}
+	constraint := c.args[0]
+	r, err := c.parseConstraint(constraint)
+	if err != nil {
+		return 0, err
+	}
+	versions, err := c.versions(c.args[1:])
+	if err != nil {
+		return 0, err
+	}
+
+	// HighestSatisfying parses a vers URI when the scheme is empty.
+	nativeScheme := *c.scheme
+	if strings.HasPrefix(constraint, versPrefix) {
+		nativeScheme = ""
+	}
+	best, err := vers.HighestSatisfying(versions, constraint, nativeScheme)
+	if err != nil {
+		return 0, err
+	}
+	result := struct {
+		Constraint string  `json:"constraint"`
+		Scheme     string  `json:"scheme,omitempty"`
+		Version    *string `json:"version"`
+	}{Constraint: constraint, Scheme: c.constraintScheme(constraint, r)}
+	if best != "" {
+		result.Version = &best
+	}
+	return exitFor(best != ""), c.output(result, best)
+}
+
+func runValidate(c *command) (int, error) {
+	if err := c.expectArgs(1, "<version>"); err != nil {
+		return 0, err
+	}
+	input := c.args[0]
+	scheme := *c.scheme
+	var valid bool
+	var reason string
+	if *c.isRange {
+		r, err := c.parseConstraint(input)
+		if err != nil {
+			if errors.Is(err, errUsage) {
+				return 0, err
+			}
+			reason = err.Error()
+		} else {
+			scheme = c.constraintScheme(input, r)
+		}
+		valid = reason == ""
+	} else {
+		if *c.scheme == "" {
+			valid = vers.Valid(input)
+		} else {
+			valid = vers.ValidWithScheme(input, *c.scheme)
+		}
+	}
+	result := struct {
+		Input  string `json:"input"`
+		Scheme string `json:"scheme,omitempty"`
+		Valid  bool   `json:"valid"`
+		Error  string `json:"error,omitempty"`
+	}{input, scheme, valid, reason}
+	text := fmt.Sprint(valid)
+	if reason != "" {
+		text += ": " + reason
+	}
+	return exitFor(valid), c.output(result, text)
+}
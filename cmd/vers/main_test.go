@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{"satisfies", "", []string{"satisfies", "1.5.0", "^1.2.3", "--scheme", "npm"}, exitOK, "true\n"},
+		{"satisfies flags first", "", []string{"satisfies", "--scheme=npm", "2.0.0", "^1.2.3"}, exitNegative, "false\n"},
+		{"satisfies vers", "", []string{"satisfies", "1.5.0", "vers:npm/>=1.0.0|<2.0.0"}, exitOK, "true\n"},
+		{"satisfies json", "", []string{"satisfies", "1.5.0", "^1.2.3", "--scheme", "npm", "--json"}, exitOK,
+			`{"version":"1.5.0","constraint":"^1.2.3","scheme":"npm","satisfies":true}` + "\n"},
+		{"satisfies vers json", "", []string{"satisfies", "2.0.0", "vers:npm/>=1.0.0|<2.0.0", "--json"}, exitNegative,
+			`{"version":"2.0.0","constraint":"vers:npm/>=1.0.0|<2.0.0","scheme":"npm","satisfies":false}` + "\n"},
+		{"compare", "", []string{"compare", "1:1.0", "2.0", "--scheme", "deb"}, exitOK, "1\n"},
+		{"compare json", "", []string{"compare", "1.0", "1.0.0", "--json"}, exitOK, `{"a":"1.0","b":"1.0.0","result":0}` + "\n"},
+		{"sort stdin", "1.0-SNAPSHOT\n1.0\n\n1.0-alpha\n", []string{"sort", "--scheme", "maven"}, exitOK, "1.0-alpha\n1.0-SNAPSHOT\n1.0\n"},
+		{"sort reverse args", "", []string{"sort", "1.2", "1.10", "1.9", "--reverse"}, exitOK, "1.10\n1.9\n1.2\n"},
+		{"sort json", "", []string{"sort", "2", "1", "--json"}, exitOK, `{"versions":["1","2"]}` + "\n"},
+		{"convert", "", []string{"convert", "^1.2.3", "--from", "npm", "--to", "vers"}, exitOK, "vers:npm/>=1.2.3|<2.0.0\n"},
+		{"convert vers", "", []string{"convert", "vers:npm/<2.0.0|>=1.2.3"}, exitOK, "vers:npm/>=1.2.3|<2.0.0\n"},
+		{"convert vers json", "", []string{"convert", "vers:npm/<2.0.0", "--json"}, exitOK,
+			`{"input":"vers:npm/<2.0.0","from":"vers","scheme":"npm","to":"vers","output":"vers:npm/<2.0.0"}` + "\n"},
+		{"normalize", "", []string{"normalize", "1.0.0-RC1", "--scheme", "pypi"}, exitOK, "1.0.0rc1\n"},
+		{"max-satisfying args", "", []string{"max-satisfying", "^1.0.0", "1.2.0", "1.10.0", "2.0.0", "--scheme", "npm"}, exitOK, "1.10.0\n"},
+		{"max-satisfying stdin", "0.9.0\n1.2.0\n", []string{"max-satisfying", "vers:npm/>=1.0.0"}, exitOK, "1.2.0\n"},
+		{"max-satisfying none", "", []string{"max-satisfying", "^3.0.0", "1.0.0", "--scheme", "npm", "--json"}, exitNegative,
+			`{"constraint":"^3.0.0","scheme":"npm","version":null}` + "\n"},
+		{"max-satisfying vers json", "", []string{"max-satisfying", "vers:pypi/<2.0", "1.10", "1.9", "2.0", "--json"}, exitOK,
+			`{"constraint":"vers:pypi/<2.0","scheme":"pypi","version":"1.10"}` + "\n"},
+		{"validate", "", []string{"validate", "1.2.3", "--scheme", "npm"}, exitOK, "true\n"},
+		{"validate invalid", "", []string{"validate", "not-a-version", "--scheme", "npm"}, exitNegative, "false\n"},
+		{"validate constraint", "", []string{"validate", "--constraint", ">=1.0,<2.0", "--scheme", "pypi"}, exitOK, "true\n"},
+		{"validate vers constraint json", "", []string{"validate", "--constraint", "vers:pypi/>=1.0", "--json"}, exitOK,
+			`{"input":"vers:pypi/>=1.0","scheme":"pypi","valid":true}` + "\n"},
+		{"after double dash", "", []string{"compare", "--scheme", "npm", "--", "-1", "1"}, exitOK, "-1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out, stderr := runCLI(t, tt.stdin, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			if out != tt.wantOut {
+				t.Errorf("stdout = %q, want %q", out, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"unknown command", []string{"frobnicate"}},
+		{"unknown flag", []string{"compare", "1", "2", "--nope"}},
+		{"missing arguments", []string{"satisfies", "1.0.0"}},
+		{"native constraint without scheme", []string{"satisfies", "1.0.0", "^1.0.0"}},
+		{"invalid constraint", []string{"satisfies", "1.0", "[1.0]", "--scheme", "gradle"}},
+		{"unsupported target", []string{"convert", "^1.0.0", "--from", "npm", "--to", "pypi"}},
+		{"unnormalizable version", []string{"normalize", "not a version", "--scheme", "pypi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out, stderr := runCLI(t, "", tt.args...)
+			if code != exitUsage {
+				t.Errorf("exit code = %d, want %d", code, exitUsage)
+			}
+			if out != "" {
+				t.Errorf("stdout = %q, want empty", out)
+			}
+			if stderr == "" {
+				t.Error("stderr is empty, want an error message")
+			}
+		})
+	}
+}
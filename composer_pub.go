@@ -549,9 +549,34 @@ func normalizeComposerVersion(version string) string {
 	return version
 }
 
-// parsePubRange parses Pub's any, caret and traditional intersection syntax.
+// parsePubRange parses Pub's any, caret and traditional intersection syntax,
+// and unions of them joined by || or by " or " as pub prints them.
 func (p *Parser) parsePubRange(constraint string) (*Range, error) {
+	alternatives, err := splitPubUnion(constraint)
+	if err != nil {
+		return nil, err
+	}
+	var result *Range
+	for _, alternative := range alternatives {
+		r, err := parsePubAlternative(alternative)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = r
+		} else {
+			result = result.Union(r)
+		}
+	}
+	return result, nil
+}
+
+// parsePubAlternative parses one alternative of a Pub union.
+func parsePubAlternative(constraint string) (*Range, error) {
 	constraint = strings.TrimSpace(constraint)
+	if constraint == pubEmptyConstraint {
+		return rangeWithScheme(&Range{}, schemePub), nil
+	}
 	if constraint == "any" {
 		return rangeWithScheme(Unbounded(), schemePub), nil
 	}
@@ -592,8 +617,10 @@ func (p *Parser) parsePubRange(constraint string) (*Range, error) {
 }
 
 // adjustPubExclusiveUpperBounds excludes prereleases of a stable upper bound
-// unless the range begins at a prerelease of that same version.
+// unless the range begins at a prerelease of that same version. It returns
+// an adjusted copy and leaves r unchanged.
 func adjustPubExclusiveUpperBounds(r *Range) *Range {
+	r = r.Clone()
 	for i := range r.Intervals {
 		interval := &r.Intervals[i]
 		if !pubUpperNeedsFirstPrerelease(*interval) {
@@ -11,7 +11,7 @@ func TestParseComposerRangeErrors(t *testing.T) {
 }
 
 func TestParsePubRangeErrors(t *testing.T) {
-	for _, constraint := range []string{"", "   ", "^1.2", "1.2.*", ">=1.0.0 || <2.0.0", ">=1.0.0, <2.0.0", "!=1.2.3"} {
+	for _, constraint := range []string{"", "   ", "^1.2", "1.2.*", ">=1.0.0 ||", "|| ^1.0.0", ">=1.0.0 | <2.0.0", ">=1.0.0, <2.0.0", "!=1.2.3"} {
 		if _, err := ParseNative(constraint, schemePub); err == nil {
 			t.Errorf("ParseNative(%q, pub) succeeded", constraint)
 		}
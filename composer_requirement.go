@@ -0,0 +1,253 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// composerInlineAliasRegex matches a whole-constraint inline alias, as in
+	// VersionParser::parseConstraints.
+	composerInlineAliasRegex = regexp.MustCompile(`^([^,\s]+) +as +([^,\s]+)$`)
+	// composerPlatformRegex matches the packages Composer's platform
+	// repository provides, as in PlatformRepository::isPlatformPackage.
+	composerPlatformRegex = regexp.MustCompile(`(?i)^(?:php(?:-64bit|-ipv6|-zts|-debug)?|hhvm|(?:ext|lib)-[a-z0-9](?:[_.-]?[a-z0-9]+)*|composer(?:-(?:plugin|runtime)-api)?)$`)
+	// composerModifierRegex matches the stability suffix VersionParser reads.
+	composerModifierRegex = regexp.MustCompile(`(?i)[._-]?(?:(stable|beta|b|rc|alpha|a|patch|pl|p)((?:[.-]?\d+)*)?)?([.-]?dev)?(?:\+.*)?$`)
+	// composerOpensslRegex matches OpenSSL versions such as 1.0.2k and
+	// 1.1.1w-fips, as in Composer's Version::parseOpenssl.
+	composerOpensslRegex = regexp.MustCompile(`^([0-9.]+)([a-z]{0,2})((?:-?(?:dev|pre|alpha|beta|rc|fips)\d*)*)(?:-\w+)?(?: \(.+?\))?$`)
+)
+
+// composerStabilityNames are Composer's stability names, indexed by the
+// composerStability constants.
+var composerStabilityNames = []string{"dev", "alpha", "beta", "RC", "stable"}
+
+// ComposerOption adjusts how ParseComposerRequirement evaluates versions.
+type ComposerOption func(*composerOptions)
+
+type composerOptions struct {
+	minimumStability string
+}
+
+// MinimumStability sets composer.json's minimum-stability: dev, alpha,
+// beta, RC or stable. The default is stable.
+func MinimumStability(stability string) ComposerOption {
+	return func(o *composerOptions) {
+		o.minimumStability = stability
+	}
+}
+
+// ComposerRequirement is one entry of a composer.json require section, such
+// as "monolog/monolog": "^2.0@beta".
+type ComposerRequirement struct {
+	// Name is the package name, such as monolog/monolog, php or ext-intl.
+	Name string
+	// Constraint is the version constraint as given.
+	Constraint string
+	// Range is the version constraint without its inline alias.
+	Range *Range
+	// Alias is the version an inline alias such as "dev-main as 1.0.x-dev"
+	// gives the package, or empty.
+	Alias string
+	// StabilityFlag is the stability the constraint allows for this package,
+	// from an explicit flag such as @dev or inferred from a version such as
+	// 1.0.0-beta, or empty if it sets none.
+	StabilityFlag string
+	// MinimumStability is the project-wide minimum-stability that applies
+	// when the constraint sets no stability flag.
+	MinimumStability string
+}
+
+// ParseComposerRequirement parses a composer.json requirement and works out
+// which stabilities it accepts, following Composer's RootPackageLoader: an
+// explicit flag such as @beta overrides minimum-stability, and failing that
+// a bare version less stable than minimum-stability, such as dev-main or
+// 2.0.0-RC1, allows its own stability. Platform packages such as php,
+// ext-* and lib-* are not subject to stability at all.
+func ParseComposerRequirement(name, constraint string, opts ...ComposerOption) (*ComposerRequirement, error) {
+	options := composerOptions{minimumStability: composerStabilityNames[composerStabilityStable]}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	minimum, ok := parseComposerStabilityName(options.minimumStability)
+	if !ok {
+		return nil, fmt.Errorf("invalid composer minimum-stability: %s", options.minimumStability)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("empty composer package name")
+	}
+
+	r, err := ParseNative(constraint, schemeComposer)
+	if err != nil {
+		return nil, err
+	}
+	req := &ComposerRequirement{
+		Name:             strings.ToLower(strings.TrimSpace(name)),
+		Constraint:       constraint,
+		Range:            r,
+		MinimumStability: composerStabilityNames[minimum],
+	}
+	if match := composerInlineAliasRegex.FindStringSubmatch(strings.TrimSpace(constraint)); match != nil {
+		req.Alias = match[2]
+	}
+	if flag, ok := composerRequirementStability(constraint, minimum); ok {
+		req.StabilityFlag = composerStabilityNames[flag]
+	}
+	return req, nil
+}
+
+// IsPlatform reports whether the requirement names a platform package,
+// which Composer takes from the running environment rather than a repository.
+func (r *ComposerRequirement) IsPlatform() bool {
+	return composerPlatformRegex.MatchString(r.Name)
+}
+
+// Contains reports whether version is a valid Composer version that satisfies
+// the constraint and is stable enough to be installed. Platform versions such
+// as 8.2.1-dev for php or 1.0.2k for lib-openssl are accepted in the form the
+// platform reports them.
+func (r *ComposerRequirement) Contains(version string) bool {
+	if r.IsPlatform() {
+		return r.Range.Contains(composerPlatformVersion(r.Name, version))
+	}
+	if !validComposerVersion(version) || composerVersionStability(version) < r.allowedStability() {
+		return false
+	}
+	return r.Range.Contains(version)
+}
+
+// allowedStability returns the least stable stability the requirement
+// accepts.
+func (r *ComposerRequirement) allowedStability() int {
+	if stability, ok := parseComposerStabilityName(r.StabilityFlag); ok {
+		return stability
+	}
+	stability, _ := parseComposerStabilityName(r.MinimumStability)
+	return stability
+}
+
+// Highest returns the version Composer would install from versions: the
+// highest one the requirement contains, or "" if none. With PreferStable,
+// as with composer.json's prefer-stable, the most stable versions win before
+// the highest, so 1.9.0 beats 2.0.0-RC1.
+func (r *ComposerRequirement) Highest(versions []string, opts ...SelectOption) string {
+	var options selectOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	best, bestStability := "", -1
+	for _, v := range versions {
+		if !r.Contains(v) {
+			continue
+		}
+		stability := composerVersionStability(v)
+		if best != "" {
+			if options.preferStable && stability != bestStability {
+				if stability < bestStability {
+					continue
+				}
+			} else if compareComposer(v, best) <= 0 {
+				continue
+			}
+		}
+		best, bestStability = v, stability
+	}
+	return best
+}
+
+// parseComposerStabilityName returns the stability a name such as beta or
+// RC denotes.
+func parseComposerStabilityName(name string) (int, bool) {
+	for stability, candidate := range composerStabilityNames {
+		if strings.EqualFold(name, candidate) {
+			return stability, true
+		}
+	}
+	return 0, false
+}
+
+// composerRequirementStability returns the stability flag a constraint sets.
+// Explicit flags win, and the least stable of them applies; otherwise bare
+// versions less stable than minimum set the flag.
+func composerRequirementStability(constraint string, minimum int) (int, bool) {
+	var parts []string
+	for _, or := range composerOrRegex.Split(strings.TrimSpace(constraint), -1) {
+		if match := composerInlineAliasRegex.FindStringSubmatch(strings.TrimSpace(or)); match != nil {
+			or = match[1]
+		}
+		parts = append(parts, strings.Fields(strings.ReplaceAll(or, ",", " "))...)
+	}
+
+	flag, found := 0, false
+	for _, part := range parts {
+		if match := composerStabilityFlagRegex.FindStringSubmatch(part); match != nil {
+			stability, _ := parseComposerStabilityName(match[2])
+			if !found || stability < flag {
+				flag, found = stability, true
+			}
+		}
+	}
+	if found {
+		return flag, true
+	}
+	for _, part := range parts {
+		if strings.Contains(part, "@") {
+			continue
+		}
+		stability := composerVersionStability(part)
+		if stability < minimum && (!found || stability < flag) {
+			flag, found = stability, true
+		}
+	}
+	return flag, found
+}
+
+// composerVersionStability returns the stability of a version or bare
+// constraint, as VersionParser::parseStability does.
+func composerVersionStability(version string) int {
+	version, _, _ = strings.Cut(version, "#")
+	version = strings.ToLower(version)
+	if strings.HasPrefix(version, "dev-") || strings.HasSuffix(version, "-dev") {
+		return composerStabilityDev
+	}
+	match := composerModifierRegex.FindStringSubmatch(version)
+	switch {
+	case match == nil:
+		return composerStabilityStable
+	case match[3] != "":
+		return composerStabilityDev
+	case match[1] == "beta" || match[1] == "b":
+		return composerStabilityBeta
+	case match[1] == "alpha" || match[1] == "a":
+		return composerStabilityAlpha
+	case match[1] == "rc":
+		return composerStabilityRC
+	}
+	return composerStabilityStable
+}
+
+// composerPlatformVersion converts a platform version Composer would
+// normalize before matching. OpenSSL 1.x letter releases such as 1.0.2k
+// become 1.0.2.11, as in Version::parseOpenssl.
+func composerPlatformVersion(name, version string) string {
+	if !strings.HasPrefix(strings.ToLower(name), "lib-") || validComposerVersion(version) {
+		return version
+	}
+	match := composerOpensslRegex.FindStringSubmatch(version)
+	if match == nil {
+		return version
+	}
+	release, letters, suffix := match[1], match[2], match[3]
+	if compareComposer(release, "3.0.0") < 0 {
+		patch := 0
+		for i := range len(letters) {
+			patch += int(letters[i]-'a') + 1
+		}
+		release += fmt.Sprintf(".%d", patch)
+	}
+	suffix = strings.ReplaceAll("-"+strings.TrimLeft(suffix, "-"), "-fips", "")
+	suffix = strings.ReplaceAll(suffix, "-pre", "-alpha")
+	return strings.TrimRight(release+suffix, "-")
+}
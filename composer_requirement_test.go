@@ -0,0 +1,138 @@
+package vers
+
+import "testing"
+
+func TestParseComposerRequirement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		constraint string
+		minimum    string
+		alias      string
+		flag       string
+	}{
+		{"monolog/monolog", "^2.0", "", "", ""},
+		{"monolog/monolog", "^2.0@beta", "", "", "beta"},
+		{"monolog/monolog", "^2.0@stable", "dev", "", "stable"},
+		{"monolog/monolog", ">=1.0@RC, <2.0@dev", "", "", "dev"},
+		{"acme/lib", "dev-main as 1.0.x-dev", "", "1.0.x-dev", "dev"},
+		{"acme/lib", "2.0.0-RC1", "", "", "RC"},
+		{"acme/lib", "2.0.0-RC1", "beta", "", ""},
+		{"acme/lib", "^1.0 || 2.0.0-beta2", "", "", "beta"},
+		{"acme/lib", "dev-feature#abc123", "", "", "dev"},
+		{"php", ">=8.1", "", "", ""},
+	}
+	for _, tt := range tests {
+		var opts []ComposerOption
+		if tt.minimum != "" {
+			opts = append(opts, MinimumStability(tt.minimum))
+		}
+		req, err := ParseComposerRequirement(tt.name, tt.constraint, opts...)
+		if err != nil {
+			t.Errorf("ParseComposerRequirement(%q, %q) error: %v", tt.name, tt.constraint, err)
+			continue
+		}
+		if req.Alias != tt.alias || req.StabilityFlag != tt.flag {
+			t.Errorf("ParseComposerRequirement(%q, %q) = %+v, want alias %q and flag %q",
+				tt.name, tt.constraint, req, tt.alias, tt.flag)
+		}
+	}
+}
+
+func TestParseComposerRequirementErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseComposerRequirement("acme/lib", "^1.0", MinimumStability("nightly")); err == nil {
+		t.Error("expected error for an unknown minimum-stability")
+	}
+	if _, err := ParseComposerRequirement("", "^1.0"); err == nil {
+		t.Error("expected error for an empty package name")
+	}
+	if _, err := ParseComposerRequirement("acme/lib", ""); err == nil {
+		t.Error("expected error for an empty constraint")
+	}
+}
+
+func TestComposerRequirementContains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		constraint string
+		minimum    string
+		contains   []string
+		excludes   []string
+	}{
+		{"acme/lib", "^2.0", "", []string{"2.0.0", "2.9.1", "2.1.0-patch1"}, []string{"2.1.0-beta1", "2.0.0-RC1", "3.0.0", "2.1.x"}},
+		{"acme/lib", "^2.0", "beta", []string{"2.1.0-beta1", "2.0.0-RC1"}, []string{"2.1.0-alpha1", "2.1.x-dev"}},
+		{"acme/lib", "^2.0@alpha", "", []string{"2.1.0-alpha1", "2.0.0"}, []string{"2.1.x-dev"}},
+		// An explicit flag overrides a looser minimum-stability.
+		{"acme/lib", "^2.0@stable", "dev", []string{"2.0.0"}, []string{"2.1.0-beta1"}},
+		{"acme/lib", "dev-main as 1.0.x-dev", "", []string{"dev-main"}, []string{"dev-develop", "1.0.0"}},
+		// Stability flags apply to the whole package, not one alternative.
+		{"acme/lib", "^1.0 || 2.0.0-beta2", "", []string{"1.5.0", "2.0.0-beta2", "1.5.0-beta1"}, []string{"1.5.0-alpha1"}},
+		// Platform packages ignore stability and accept platform version forms.
+		{"php", ">=8.2", "", []string{"8.2.1-dev", "8.3.0"}, []string{"8.1.9"}},
+		{"ext-intl", "*", "", []string{"72.1", "1.0.0-alpha"}, nil},
+		{"lib-openssl", ">=1.0.2.11 <3.1", "", []string{"1.0.2k", "1.1.1w-fips", "3.0.2"}, []string{"1.0.2j", "3.1.0"}},
+	}
+	for _, tt := range tests {
+		var opts []ComposerOption
+		if tt.minimum != "" {
+			opts = append(opts, MinimumStability(tt.minimum))
+		}
+		req, err := ParseComposerRequirement(tt.name, tt.constraint, opts...)
+		if err != nil {
+			t.Fatalf("ParseComposerRequirement(%q, %q) error: %v", tt.name, tt.constraint, err)
+		}
+		for _, v := range tt.contains {
+			if !req.Contains(v) {
+				t.Errorf("%s %q (minimum %q).Contains(%q) = false, want true", tt.name, tt.constraint, tt.minimum, v)
+			}
+		}
+		for _, v := range tt.excludes {
+			if req.Contains(v) {
+				t.Errorf("%s %q (minimum %q).Contains(%q) = true, want false", tt.name, tt.constraint, tt.minimum, v)
+			}
+		}
+	}
+}
+
+func TestComposerRequirementHighest(t *testing.T) {
+	t.Parallel()
+
+	req, err := ParseComposerRequirement("acme/lib", "^1.0 || ^2.0", MinimumStability("RC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []string{"1.8.0", "1.9.0", "2.0.0-RC1", "2.0.0-beta1"}
+	if got := req.Highest(versions); got != "2.0.0-RC1" {
+		t.Errorf("Highest = %q, want 2.0.0-RC1", got)
+	}
+	if got := req.Highest(versions, PreferStable()); got != "1.9.0" {
+		t.Errorf("Highest with PreferStable = %q, want 1.9.0", got)
+	}
+	if got := req.Highest([]string{"3.0.0"}); got != "" {
+		t.Errorf("Highest with no match = %q, want empty", got)
+	}
+}
+
+func TestComposerPlatformVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"1.0.2k":         "1.0.2.11",
+		"1.0.2za":        "1.0.2.27",
+		"1.1.1w-fips":    "1.1.1.23",
+		"1.1.0-pre3":     "1.1.0.0-alpha3",
+		"3.0.2":          "3.0.2",
+		"1.0.1e (fips)":  "1.0.1.5",
+		"not-a-version!": "not-a-version!",
+	}
+	for input, want := range tests {
+		if got := composerPlatformVersion("lib-openssl", input); got != want {
+			t.Errorf("composerPlatformVersion(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
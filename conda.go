@@ -0,0 +1,434 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Conda VersionOrder parsing and comparison.
+// https://github.com/conda/conda/blob/main/conda/models/version.py
+//
+// A version is lowercased and split into an epoch, a dotted version and an
+// optional +local part. Underscores separate components like dots, and dashes
+// do too when the version has no underscores. Every component is split into
+// runs of digits, asterisks and other characters. "dev" sorts before every
+// other string, "post" sorts after every number, strings sort before numbers,
+// and missing components compare as 0, so 1.1 == 1.1.0.
+
+const (
+	condaPartNumber = iota
+	condaPartString
+	condaPartPost
+)
+
+type condaPart struct {
+	kind  int
+	value string
+}
+
+type condaVersion struct {
+	version [][]condaPart
+	local   [][]condaPart
+}
+
+// condaFill is the value a missing component or run compares as.
+var condaFill = condaPart{kind: condaPartNumber, value: "0"}
+
+// normalizeCondaVersion returns the lowercased form VersionOrder parses,
+// converting dashes to underscores when that makes the version valid.
+func normalizeCondaVersion(version string) (string, bool) {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" {
+		return "", false
+	}
+	if !containsOnly(version, isCondaVersionChar) {
+		if !strings.Contains(version, "-") || strings.Contains(version, "_") {
+			return "", false
+		}
+		version = strings.ReplaceAll(version, "-", "_")
+		if !containsOnly(version, isCondaVersionChar) {
+			return "", false
+		}
+	}
+	return version, true
+}
+
+func isCondaVersionChar(c byte) bool {
+	return isASCIIDigit(c) || c >= 'a' && c <= 'z' || strings.IndexByte("*.+!_", c) >= 0
+}
+
+func parseCondaVersion(s string) (condaVersion, bool) {
+	version, ok := normalizeCondaVersion(s)
+	if !ok {
+		return condaVersion{}, false
+	}
+
+	epoch := "0"
+	if parts := strings.Split(version, "!"); len(parts) == 2 { //nolint:mnd
+		if !isDigits(parts[0]) {
+			return condaVersion{}, false
+		}
+		epoch, version = parts[0], parts[1]
+	} else if len(parts) > 2 { //nolint:mnd
+		return condaVersion{}, false
+	}
+
+	var parsed condaVersion
+	var local string
+	hasLocal := false
+	if parts := strings.Split(version, "+"); len(parts) == 2 { //nolint:mnd
+		version, local, hasLocal = parts[0], parts[1], true
+	} else if len(parts) > 2 { //nolint:mnd
+		return condaVersion{}, false
+	}
+	if version == "" {
+		return condaVersion{}, false
+	}
+
+	var components []string
+	if strings.HasSuffix(version, "_") {
+		// A trailing underscore marks OpenSSL-like versions and stays part of
+		// the last component instead of starting a new one.
+		components = strings.Split(strings.ReplaceAll(version[:len(version)-1], "_", "."), ".")
+		components[len(components)-1] += "_"
+	} else {
+		components = strings.Split(strings.ReplaceAll(version, "_", "."), ".")
+	}
+	if parsed.version, ok = parseCondaComponents(append([]string{epoch}, components...)); !ok {
+		return condaVersion{}, false
+	}
+	if hasLocal {
+		if parsed.local, ok = parseCondaComponents(strings.Split(strings.ReplaceAll(local, "_", "."), ".")); !ok {
+			return condaVersion{}, false
+		}
+	}
+	return parsed, true
+}
+
+func parseCondaComponents(components []string) ([][]condaPart, bool) {
+	result := make([][]condaPart, 0, len(components))
+	for _, component := range components {
+		if component == "" {
+			return nil, false
+		}
+		var parts []condaPart
+		for i := 0; i < len(component); {
+			start := i
+			switch {
+			case isASCIIDigit(component[i]):
+				for i < len(component) && isASCIIDigit(component[i]) {
+					i++
+				}
+				parts = append(parts, condaPart{kind: condaPartNumber, value: component[start:i]})
+				continue
+			case component[i] == '*':
+				for i < len(component) && component[i] == '*' {
+					i++
+				}
+			default:
+				for i < len(component) && !isASCIIDigit(component[i]) && component[i] != '*' {
+					i++
+				}
+			}
+			switch run := component[start:i]; run {
+			case "post":
+				parts = append(parts, condaPart{kind: condaPartPost})
+			case "dev":
+				parts = append(parts, condaPart{kind: condaPartString, value: "DEV"})
+			default:
+				parts = append(parts, condaPart{kind: condaPartString, value: run})
+			}
+		}
+		if !isASCIIDigit(component[0]) {
+			parts = append([]condaPart{condaFill}, parts...)
+		}
+		result = append(result, parts)
+	}
+	return result, true
+}
+
+// isCondaPrerelease reports whether a conda version has a letter run other
+// than post outside its local part, such as 1.0a1 or 1.0.dev0. Such runs sort
+// before the numbers they stand in for, so the version precedes its release.
+func isCondaPrerelease(version string) bool {
+	parsed, ok := parseCondaVersion(version)
+	if !ok {
+		return false
+	}
+	for _, component := range parsed.version {
+		for _, part := range component {
+			if part.kind == condaPartString && part.value != "*" && part.value != "_" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareConda compares two conda versions using VersionOrder semantics.
+// Falls back to generic comparison if either side is not a valid version.
+func compareConda(a, b string) int {
+	left, leftOK := parseCondaVersion(a)
+	right, rightOK := parseCondaVersion(b)
+	if !leftOK || !rightOK {
+		return CompareVersions(a, b)
+	}
+	if c := compareCondaComponents(left.version, right.version); c != 0 {
+		return c
+	}
+	return compareCondaComponents(left.local, right.local)
+}
+
+func compareCondaComponents(a, b [][]condaPart) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var left, right []condaPart
+		if i < len(a) {
+			left = a[i]
+		}
+		if i < len(b) {
+			right = b[i]
+		}
+		for j := 0; j < len(left) || j < len(right); j++ {
+			l, r := condaFill, condaFill
+			if j < len(left) {
+				l = left[j]
+			}
+			if j < len(right) {
+				r = right[j]
+			}
+			if c := compareCondaPart(l, r); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func compareCondaPart(a, b condaPart) int {
+	aString, bString := a.kind == condaPartString, b.kind == condaPartString
+	switch {
+	case aString && bString:
+		return cmpString(a.value, b.value)
+	case aString:
+		return -1
+	case bString:
+		return 1
+	case a.kind == condaPartPost || b.kind == condaPartPost:
+		return cmpInt(boolInt(a.kind == condaPartPost), boolInt(b.kind == condaPartPost))
+	default:
+		return cmpNumStr(a.value, b.value)
+	}
+}
+
+// condaParser is a recursive-descent parser over a MatchSpec version
+// expression, where , binds tighter than |.
+type condaParser struct {
+	input  string
+	tokens []string
+	pos    int
+}
+
+// parseCondaRange parses conda's MatchSpec version syntax.
+func (p *Parser) parseCondaRange(constraint string) (*Range, error) {
+	tokens := tokenizeCondaConstraint(constraint)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty conda constraint")
+	}
+	parser := &condaParser{input: constraint, tokens: tokens}
+	r, err := parser.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos != len(tokens) {
+		return nil, parser.errorf("unexpected %q", tokens[parser.pos])
+	}
+	return r, nil
+}
+
+// tokenizeCondaConstraint splits a version expression into parentheses,
+// separators and trimmed version terms.
+func tokenizeCondaConstraint(constraint string) []string {
+	var tokens []string
+	term := strings.Builder{}
+	flush := func() {
+		if value := strings.TrimSpace(term.String()); value != "" {
+			tokens = append(tokens, value)
+		}
+		term.Reset()
+	}
+	for i := 0; i < len(constraint); i++ {
+		switch c := constraint[i]; c {
+		case '(', ')', ',', '|':
+			flush()
+			tokens = append(tokens, string(c))
+		default:
+			term.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func (cp *condaParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid conda constraint %q: %s", cp.input, fmt.Sprintf(format, args...))
+}
+
+func (cp *condaParser) peek(value string) bool {
+	return cp.pos < len(cp.tokens) && cp.tokens[cp.pos] == value
+}
+
+func (cp *condaParser) parseUnion() (*Range, error) {
+	result, err := cp.parseIntersection()
+	if err != nil {
+		return nil, err
+	}
+	for cp.peek("|") {
+		cp.pos++
+		next, err := cp.parseIntersection()
+		if err != nil {
+			return nil, err
+		}
+		result = result.Union(next)
+	}
+	return result, nil
+}
+
+func (cp *condaParser) parseIntersection() (*Range, error) {
+	result, err := cp.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for cp.peek(",") {
+		cp.pos++
+		next, err := cp.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		result = result.Intersect(next)
+	}
+	return result, nil
+}
+
+func (cp *condaParser) parseAtom() (*Range, error) {
+	if cp.pos >= len(cp.tokens) {
+		return nil, cp.errorf("unexpected end of constraint")
+	}
+	token := cp.tokens[cp.pos]
+	cp.pos++
+	switch token {
+	case "(":
+		r, err := cp.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if !cp.peek(")") {
+			return nil, cp.errorf("missing closing parenthesis")
+		}
+		cp.pos++
+		return r, nil
+	case ")", ",", "|":
+		return nil, cp.errorf("unexpected %q", token)
+	default:
+		return parseCondaTerm(token)
+	}
+}
+
+// parseCondaTerm expands one version term. A version without an operator,
+// a trailing * and the = operator all match by prefix, so 1.2, 1.2.* and =1.2
+// all match 1.2.0 and 1.2.5 but not 1.20; == matches exactly.
+func parseCondaTerm(term string) (*Range, error) {
+	if term == "*" {
+		return rangeWithScheme(Unbounded(), schemeConda), nil
+	}
+	if strings.HasPrefix(term, "^") || strings.HasSuffix(term, "$") {
+		return nil, fmt.Errorf("conda regular expression constraints are not supported: %s", term)
+	}
+
+	operator := ""
+	for _, candidate := range []string{"==", "!=", "<=", ">=", "~=", "<", ">", "="} {
+		if strings.HasPrefix(term, candidate) {
+			operator = candidate
+			break
+		}
+	}
+	version := strings.TrimSpace(term[len(operator):])
+	if version == "" || strings.IndexAny(version[:1], "=<>!~") == 0 {
+		return nil, fmt.Errorf("invalid conda constraint: %s", term)
+	}
+
+	wildcard := strings.HasSuffix(version, "*")
+	if wildcard {
+		version = strings.TrimSuffix(strings.TrimSuffix(version, "*"), ".")
+	}
+	if operator == "" {
+		// MatchSpec reads a bare version as a fuzzy prefix match.
+		operator = "="
+	}
+	if operator == "=" {
+		wildcard = true
+	}
+
+	normalized, ok := normalizeCondaVersion(version)
+	if _, valid := parseCondaVersion(normalized); !ok || !valid {
+		return nil, fmt.Errorf("invalid conda version: %s", version)
+	}
+
+	var intervals []Interval
+	var exclusions []string
+	switch {
+	case operator == "~=":
+		if wildcard {
+			return nil, fmt.Errorf("conda ~= cannot be combined with a wildcard: %s", term)
+		}
+		dot := strings.LastIndexByte(normalized, '.')
+		if dot <= 0 {
+			return nil, fmt.Errorf("invalid conda compatible release: %s", term)
+		}
+		_, upper, err := condaPrefixBounds(normalized[:dot])
+		if err != nil {
+			return nil, err
+		}
+		intervals = []Interval{NewInterval(normalized, upper, true, false)}
+	case wildcard && (operator == "=" || operator == "=="):
+		lower, upper, err := condaPrefixBounds(normalized)
+		if err != nil {
+			return nil, err
+		}
+		intervals = []Interval{NewInterval(lower, upper, true, false)}
+	case wildcard && operator == "!=":
+		lower, upper, err := condaPrefixBounds(normalized)
+		if err != nil {
+			return nil, err
+		}
+		intervals = []Interval{LessThanInterval(lower, false), GreaterThanInterval(upper, true)}
+	case operator == "!=":
+		intervals = []Interval{UnboundedInterval()}
+		exclusions = []string{version}
+	case operator == "==":
+		intervals = []Interval{ExactInterval(version)}
+	default:
+		// A wildcard on an ordering operator is superfluous and ignored.
+		constraint, err := parseConstraintWithScheme(operator+version, schemeConda)
+		if err != nil {
+			return nil, err
+		}
+		interval, _ := constraint.ToInterval()
+		intervals = []Interval{interval}
+	}
+	return &Range{Intervals: intervals, Exclusions: exclusions, Scheme: schemeConda}, nil
+}
+
+// condaPrefixBounds returns the bounds of every version that starts with the
+// prefix. A trailing * sorts before every other run, so 1.2* is the lowest
+// version starting with 1.2 and 1.3* is the first one past it.
+func condaPrefixBounds(prefix string) (string, string, error) {
+	end := len(prefix)
+	start := end
+	for start > 0 && isASCIIDigit(prefix[start-1]) {
+		start--
+	}
+	if start == end || strings.Contains(prefix, "+") {
+		return "", "", fmt.Errorf("unsupported conda prefix match: %s.*", prefix)
+	}
+	return prefix + "*", prefix[:start] + incNumStr(prefix[start:end]) + "*", nil
+}
@@ -0,0 +1,183 @@
+package vers
+
+import "testing"
+
+// condaVersionOrder is conda's VersionOrder test list, in ascending order.
+// Adjacent entries in the same group compare equal.
+var condaVersionOrder = [][]string{
+	{"   0.4", "0.4.0"},
+	{"0.4.1a.vc11"},
+	{"0.4.1.rc"},
+	{"0.4.1.vc11"},
+	{"0.4.1"},
+	{"0.5*"},
+	{"0.5a1"},
+	{"0.5b3"},
+	{"0.5C1"},
+	{"0.5z"},
+	{"0.5za"},
+	{"0.5"},
+	{"0.5_5", "0.5-5"},
+	{"0.9.6"},
+	{"0.960923"},
+	{"1.0"},
+	{"1.0.4a3"},
+	{"1.0.4b1"},
+	{"1.0.4"},
+	{"1.1dev1"},
+	{"1.1_"},
+	{"1.1a1"},
+	{"1.1.dev1", "1.1.0dev1"},
+	{"1.1.a1", "1.1.0a1"},
+	{"1.1.0"},
+	{"1.1.0post1", "1.1.post1"},
+	{"1.1post1"},
+	{"1996.07.12"},
+	{"1!0.4.1"},
+	{"1!3.1.1.6"},
+	{"2!0.4.1"},
+}
+
+func TestCondaVersionOrder(t *testing.T) {
+	t.Parallel()
+
+	var flat []string
+	var group []int
+	for index, versions := range condaVersionOrder {
+		for _, version := range versions {
+			flat = append(flat, version)
+			group = append(group, index)
+		}
+	}
+	for i := range flat {
+		for j := range flat {
+			want := cmpInt(group[i], group[j])
+			if got := CompareWithScheme(flat[i], flat[j], "conda"); got != want {
+				t.Errorf("CompareWithScheme(%q, %q, conda) = %d, want %d", flat[i], flat[j], got, want)
+			}
+		}
+	}
+}
+
+func TestCondaVersionEquality(t *testing.T) {
+	t.Parallel()
+
+	tests := [][2]string{
+		{"0.4.1.rc", "  0.4.1.RC  "},
+		{"0.4", "0.4.0"},
+		{"0.4.a1", "0.4.0a1"},
+		{"1.0+abc.1", "1.0+ABC_1"},
+	}
+	for _, tt := range tests {
+		if got := CompareWithScheme(tt[0], tt[1], "conda"); got != 0 {
+			t.Errorf("CompareWithScheme(%q, %q, conda) = %d, want 0", tt[0], tt[1], got)
+		}
+	}
+	if CompareWithScheme("0.4", "0.4.1", "conda") == 0 || CompareWithScheme("0.4.a1", "0.4.1a1", "conda") == 0 {
+		t.Error("different conda versions compared equal")
+	}
+	if CompareWithScheme("1.0", "1.0+1", "conda") >= 0 {
+		t.Error("a local version should sort after its public version")
+	}
+}
+
+func TestCondaVersionValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{"", "  ", "3.5&1", "5.5++", "5.5..mw", "!", "a!1.0", "a!b!1.0", "1.0-2_3"} {
+		if ValidWithScheme(version, "conda") {
+			t.Errorf("ValidWithScheme(%q, conda) = true, want false", version)
+		}
+	}
+	for _, version := range []string{"1.0", "1!2.0+local_1", "1.1_", "2.0-beta-1", "1.0.post1"} {
+		if !ValidWithScheme(version, "conda") {
+			t.Errorf("ValidWithScheme(%q, conda) = false, want true", version)
+		}
+	}
+	if got, err := NormalizeWithScheme(" 2.0-Beta-1 ", "conda"); err != nil || got != "2.0_beta_1" {
+		t.Errorf("NormalizeWithScheme(2.0-Beta-1, conda) = %q, %v", got, err)
+	}
+}
+
+func TestParseCondaRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.2.*", "1.2", true},
+		{"1.2.*", "1.2.0", true},
+		{"1.2.*", "1.2.7", true},
+		{"1.2.*", "1.2a1", true},
+		{"1.2.*", "1.2.0dev1", true},
+		{"1.2.*", "1.20", false},
+		{"1.2.*", "1.3", false},
+		{"1.2.*", "1.1.9", false},
+		{"1.2*", "1.2.3", true},
+		{"1.2", "1.2.3", true},
+		{"1.2", "1.3", false},
+		{"=1.2", "1.2.3", true},
+		{"==1.2", "1.2.0", true},
+		{"==1.2", "1.2.3", false},
+		{">=1.0,<2|3.0", "1.5", true},
+		{">=1.0,<2|3.0", "2.5", false},
+		{">=1.0,<2|3.0", "3.0.4", true},
+		{"1.2|1.4", "1.4.1", true},
+		{"1.2|1.4", "1.3", false},
+		{"!=1.5", "1.5.0", false},
+		{"!=1.5", "1.5.1", true},
+		{"!=1.5.*", "1.5.1", false},
+		{"!=1.5.*", "1.6", true},
+		{"!=1.5.*", "1.4", true},
+		{"~=1.2.3", "1.2.9", true},
+		{"~=1.2.3", "1.2.2", false},
+		{"~=1.2.3", "1.3", false},
+		{"~=1.2", "1.9", true},
+		{"~=1.2", "2.0", false},
+		{">=1.2.*", "1.2.0", true},
+		{"(>=1,<2)|(>=3,<4)", "3.5", true},
+		{"(>=1,<2)|(>=3,<4)", "2.5", false},
+		{">=1,(<2|>3)", "3.5", true},
+		{">= 1.0, < 2.0", "1.9", true},
+		{"*", "0.0.1", true},
+		{">=1!1.0", "2.0", false},
+		{"1!2.*", "1!2.5", true},
+		{"1!2.*", "2.5", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, "conda")
+		if err != nil {
+			t.Errorf("ParseNative(%q, conda) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("ParseNative(%q, conda).Contains(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseCondaCompatibleReleaseNormalized(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseNative("~=1.2.RC1", "conda")
+	if err != nil {
+		t.Fatalf("ParseNative(~=1.2.RC1, conda) error: %v", err)
+	}
+	if got := r.Intervals[0].Min; got != "1.2.rc1" {
+		t.Errorf("ParseNative(~=1.2.RC1, conda) lower bound = %q, want 1.2.rc1", got)
+	}
+}
+
+func TestParseCondaRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{
+		"", ">=", "1.0,", "(1.0", "1.0)", "===1.0", "~=1", "~=1.2.*", "^1\\.2.*$", "1.0&1", "1.2a.*", "1.0+local.*",
+	} {
+		if _, err := ParseNative(constraint, "conda"); err == nil {
+			t.Errorf("ParseNative(%q, conda) expected error", constraint)
+		}
+	}
+}
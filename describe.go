@@ -0,0 +1,154 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DescriptionTemplates holds the phrases Describe builds descriptions from,
+// so they can be translated. Templates are fmt formats whose arguments are
+// versions; use explicit indexes such as %[2]s to reorder them.
+type DescriptionTemplates struct {
+	Any  string // every version
+	None string // no version
+
+	Exact   string // one version
+	AtLeast string // an inclusive minimum
+	Above   string // an exclusive minimum
+	AtMost  string // an inclusive maximum
+	Below   string // an exclusive maximum
+
+	// Bounded intervals get the minimum then the maximum.
+	FromUpTo     string // [min, max)
+	FromThrough  string // [min, max]
+	AboveUpTo    string // (min, max)
+	AboveThrough string // (min, max]
+
+	// Except gets the description and the list of excluded versions.
+	Except string
+
+	// Lists join intervals with Or and exclusions with And. ListSeparator
+	// separates every item but the last two.
+	ListSeparator string
+	Or            string
+	And           string
+}
+
+// DefaultDescriptionTemplates returns the English templates Describe uses.
+func DefaultDescriptionTemplates() DescriptionTemplates {
+	return DescriptionTemplates{
+		Any:           "any version",
+		None:          "no version",
+		Exact:         "exactly %s",
+		AtLeast:       "any version from %s",
+		Above:         "any version above %s",
+		AtMost:        "any version up to and including %s",
+		Below:         "any version below %s",
+		FromUpTo:      "%s up to but not including %s",
+		FromThrough:   "%s through %s",
+		AboveUpTo:     "above %s up to but not including %s",
+		AboveThrough:  "above %s up to and including %s",
+		Except:        "%s, except %s",
+		ListSeparator: ", ",
+		Or:            " or ",
+		And:           " and ",
+	}
+}
+
+// Describe renders the range as an English phrase such as
+// "1.0.0 up to but not including 2.0.0, except 1.5.0".
+func (r *Range) Describe() string {
+	return r.DescribeWith(DefaultDescriptionTemplates())
+}
+
+// DescribeWith renders the range as a phrase built from templates. Bounds
+// that a scheme's parser made up to express a range, such as the 2.0.0-0
+// of npm's ^1.2.0 or the 1.3* of conda's 1.2, are shown as the versions
+// they stand for.
+func (r *Range) DescribeWith(templates DescriptionTemplates) string {
+	cmp := compareFuncFor(r.Scheme)
+	scheme := canonicalScheme(r.Scheme)
+	var parts []string
+	for _, interval := range r.Intervals {
+		if interval.isEmptyCmp(cmp) {
+			continue
+		}
+		parts = append(parts, templates.describeInterval(displayInterval(interval, scheme), cmp))
+	}
+	if len(parts) == 0 {
+		return templates.None
+	}
+
+	description := templates.list(parts, templates.Or)
+	if len(r.Exclusions) > 0 {
+		description = fmt.Sprintf(templates.Except, description, templates.list(r.Exclusions, templates.And))
+	}
+	return description
+}
+
+func (t DescriptionTemplates) describeInterval(interval Interval, cmp func(a, b string) int) string {
+	switch {
+	case interval.IsUnbounded():
+		return t.Any
+	case interval.Min != "" && interval.Max != "" && interval.MinInclusive && interval.MaxInclusive && cmp(interval.Min, interval.Max) == 0:
+		return fmt.Sprintf(t.Exact, interval.Min)
+	case interval.Max == "":
+		if interval.MinInclusive {
+			return fmt.Sprintf(t.AtLeast, interval.Min)
+		}
+		return fmt.Sprintf(t.Above, interval.Min)
+	case interval.Min == "":
+		if interval.MaxInclusive {
+			return fmt.Sprintf(t.AtMost, interval.Max)
+		}
+		return fmt.Sprintf(t.Below, interval.Max)
+	}
+
+	template := t.FromUpTo
+	switch {
+	case interval.MinInclusive && interval.MaxInclusive:
+		template = t.FromThrough
+	case !interval.MinInclusive && interval.MaxInclusive:
+		template = t.AboveThrough
+	case !interval.MinInclusive:
+		template = t.AboveUpTo
+	}
+	return fmt.Sprintf(template, interval.Min, interval.Max)
+}
+
+// displayInterval replaces the sentinel bounds scheme parsers produce with
+// the versions a reader expects. Upper bounds such as 2.0.0-0, 1.3-dev and
+// 2.a sit just below a release to keep its prereleases out, and read as that
+// release; conda prefix bounds such as 1.2* read as the prefix; and
+// Terraform's ~> 1.2 bound of 2 reads as 2.0.0.
+func displayInterval(interval Interval, scheme string) Interval {
+	if scheme == schemeConda {
+		interval.Min = strings.TrimSuffix(interval.Min, "*")
+		interval.Max = strings.TrimSuffix(interval.Max, "*")
+		return interval
+	}
+	if interval.Max == "" || interval.MaxInclusive {
+		return interval
+	}
+	switch scheme {
+	case schemeNPM, schemeSemVer, schemeCargo, schemePub, schemeNuGet, schemeGo, schemeHex:
+		interval.Max = strings.TrimSuffix(interval.Max, "-0")
+	case schemeGradle, schemeComposer:
+		interval.Max = strings.TrimSuffix(interval.Max, "-dev")
+	case schemeGem:
+		interval.Max = strings.TrimSuffix(interval.Max, ".a")
+	case schemeTerraform:
+		for strings.Count(interval.Max, ".") < 2 && isDigits(strings.ReplaceAll(interval.Max, ".", "")) {
+			interval.Max += ".0"
+		}
+	}
+	return interval
+}
+
+// list joins items as "a, b or c".
+func (t DescriptionTemplates) list(items []string, last string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], t.ListSeparator) + last + items[len(items)-1]
+}
@@ -0,0 +1,100 @@
+package vers
+
+import "testing"
+
+func TestRangeDescribe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		r    *Range
+		want string
+	}{
+		{"bounded with exclusion", NewRange([]Interval{NewInterval("1.0.0", "2.0.0", true, false)}).Exclude("1.5.0"),
+			"1.0.0 up to but not including 2.0.0, except 1.5.0"},
+		{"at least", GreaterThan("3.2", true), "any version from 3.2"},
+		{"above", GreaterThan("3.2", false), "any version above 3.2"},
+		{"at most", LessThan("2.0", true), "any version up to and including 2.0"},
+		{"below", LessThan("2.0", false), "any version below 2.0"},
+		{"through", NewRange([]Interval{NewInterval("1.0", "2.0", true, true)}), "1.0 through 2.0"},
+		{"above up to", NewRange([]Interval{NewInterval("1.0", "2.0", false, false)}), "above 1.0 up to but not including 2.0"},
+		{"above through", NewRange([]Interval{NewInterval("1.0", "2.0", false, true)}), "above 1.0 up to and including 2.0"},
+		{"exact", Exact("1.2.3"), "exactly 1.2.3"},
+		{"unbounded", Unbounded(), "any version"},
+		{"unbounded with exclusions", Unbounded().Exclude("1.0").Exclude("2.0").Exclude("3.0"), "any version, except 1.0, 2.0 and 3.0"},
+		{"empty", Empty(), "no version"},
+		{"no intervals", NewRange(nil), "no version"},
+		{"multiple intervals", NewRange([]Interval{
+			NewInterval("1.0", "2.0", true, false), ExactInterval("2.5"), GreaterThanInterval("3.0", true),
+		}), "1.0 up to but not including 2.0, exactly 2.5 or any version from 3.0"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Describe(); got != tt.want {
+			t.Errorf("%s: Describe() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRangeDescribeWith(t *testing.T) {
+	t.Parallel()
+
+	templates := DefaultDescriptionTemplates()
+	templates.FromUpTo = "von %s bis ausschließlich %s"
+	templates.AtLeast = "ab %s"
+	templates.Except = "%[1]s, außer %[2]s"
+	templates.Or = " oder "
+	templates.And = " und "
+
+	r := NewRange([]Interval{NewInterval("1.0", "2.0", true, false), GreaterThanInterval("3.0", true)}).Exclude("1.5").Exclude("1.6")
+	want := "von 1.0 bis ausschließlich 2.0 oder ab 3.0, außer 1.5 und 1.6"
+	if got := r.DescribeWith(templates); got != want {
+		t.Errorf("DescribeWith() = %q, want %q", got, want)
+	}
+
+	reordered := DefaultDescriptionTemplates()
+	reordered.FromThrough = "up to %[2]s from %[1]s"
+	if got := NewRange([]Interval{NewInterval("1.0", "2.0", true, true)}).DescribeWith(reordered); got != "up to 2.0 from 1.0" {
+		t.Errorf("DescribeWith() with indexed verbs = %q", got)
+	}
+}
+
+func TestRangeDescribeSchemeBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint, scheme string
+		want               string
+	}{
+		{"^1.2.0", "pub", "1.2.0 up to but not including 2.0.0"},
+		{"^1.2.0", "cargo", "1.2.0 up to but not including 2.0.0"},
+		{"1.*", "nuget", "1.0.0 up to but not including 2.0.0"},
+		{"1.2.+", "gradle", "above 1.2 up to but not including 1.3"},
+		{"1.2", "conda", "1.2 up to but not including 1.3"},
+		{"!=1.5.*", "conda", "any version below 1.5 or any version from 1.6"},
+		{"~> 1.2", "terraform", "1.2 up to but not including 2.0.0"},
+		{"~> 1.2.3", "opentofu", "1.2.3 up to but not including 1.3.0"},
+		{"~> 1.2", "gem", "1.2 up to but not including 2"},
+		{"^1.2", "composer", "1.2.0-dev up to but not including 2.0.0"},
+		{"<2.0.0-0", "npm", "any version below 2.0.0"},
+		{"<=2.0.0-0", "npm", "any version up to and including 2.0.0-0"},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, tt.scheme)
+		if err != nil {
+			t.Errorf("ParseNative(%q, %s) error: %v", tt.constraint, tt.scheme, err)
+			continue
+		}
+		if got := r.Describe(); got != tt.want {
+			t.Errorf("ParseNative(%q, %s).Describe() = %q, want %q", tt.constraint, tt.scheme, got, tt.want)
+		}
+	}
+
+	// Go has no native prefix syntax; go get's v1.2 query makes the same bound.
+	q, err := ParseGoQuery("v1.2")
+	if err != nil {
+		t.Fatalf("ParseGoQuery(v1.2) error: %v", err)
+	}
+	if got, want := q.Range().Describe(), "v1.2 up to but not including v1.3.0"; got != want {
+		t.Errorf("ParseGoQuery(v1.2).Range().Describe() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,285 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind classifies the difference between two versions.
+type ChangeKind int
+
+const (
+	// ChangeNone means the versions are the same release.
+	ChangeNone ChangeKind = iota
+	// ChangeBuild means only build metadata, a local version or a package
+	// revision changed.
+	ChangeBuild
+	// ChangePrerelease means only the prerelease part of the same release changed.
+	ChangePrerelease
+	// ChangePatch is a backwards compatible fix.
+	ChangePatch
+	// ChangeMinor is a backwards compatible feature release.
+	ChangeMinor
+	// ChangeMajor is a breaking change under the scheme's conventions.
+	ChangeMajor
+)
+
+// String returns the lowercase name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeNone:
+		return "none"
+	case ChangeBuild:
+		return "build"
+	case ChangePrerelease:
+		return "prerelease"
+	case ChangePatch:
+		return "patch"
+	case ChangeMinor:
+		return "minor"
+	case ChangeMajor:
+		return "major"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// diffParts is a version split into the pieces Diff compares.
+type diffParts struct {
+	epoch   string
+	release []string
+	pre     string
+	post    string
+	build   string
+}
+
+// diffConvention says which release component changes are breaking.
+type diffConvention int
+
+const (
+	// diffSemver treats the first release component as the major version.
+	diffSemver diffConvention = iota
+	// diffZeroMajor also treats the first non-zero component of a 0.x
+	// version as breaking, as npm, Cargo, pub and Composer carets do.
+	diffZeroMajor
+	// diffPVP treats the first two components as the major version, per the
+	// Haskell Package Versioning Policy.
+	diffPVP
+)
+
+// Diff classifies the change from one version to another under a scheme's
+// versioning conventions:
+//   - npm, cargo, pub and composer: a 0.x minor bump and a 0.0.x patch bump
+//     are breaking, matching their caret ranges
+//   - pypi: epochs and the first release segment are breaking, post-releases
+//     are patches and local versions are builds
+//   - maven, gradle and nuget: the fourth and later numbers are patches
+//   - deb and rpm: epochs are breaking and the Debian revision or RPM
+//     release is a build
+//   - hackage: A.B is the major version, C the minor and D the patch
+//
+// The direction does not matter, so a downgrade is classified like the
+// matching upgrade.
+func Diff(from, to, scheme string) (ChangeKind, error) {
+	scheme = canonicalScheme(scheme)
+	a, err := diffPartsFor(from, scheme)
+	if err != nil {
+		return ChangeNone, err
+	}
+	b, err := diffPartsFor(to, scheme)
+	if err != nil {
+		return ChangeNone, err
+	}
+
+	convention := diffSemver
+	switch scheme {
+	case schemeNPM, schemeCargo, schemePub, schemeComposer:
+		convention = diffZeroMajor
+	case schemeHackage:
+		convention = diffPVP
+	}
+	return classifyDiff(a, b, convention), nil
+}
+
+func classifyDiff(a, b diffParts, convention diffConvention) ChangeKind {
+	if cmpNumStr(a.epoch, b.epoch) != 0 {
+		return ChangeMajor
+	}
+
+	length := max(len(a.release), len(b.release), 3) //nolint:mnd
+	for i := range length {
+		if cmpNumStr(releasePart(a.release, i), releasePart(b.release, i)) == 0 {
+			continue
+		}
+		switch convention {
+		case diffPVP:
+			if i < 2 { //nolint:mnd
+				return ChangeMajor
+			}
+			if i == 2 { //nolint:mnd
+				return ChangeMinor
+			}
+			return ChangePatch
+		case diffZeroMajor:
+			// The first non-zero component shared by both versions is the major one.
+			leadingZeros := 0
+			for leadingZeros < i && releasePart(a.release, leadingZeros) == "0" {
+				leadingZeros++
+			}
+			if leadingZeros == i {
+				return ChangeMajor
+			}
+			if leadingZeros > 0 {
+				return ChangePatch
+			}
+		}
+		switch i {
+		case 0:
+			return ChangeMajor
+		case 1:
+			return ChangeMinor
+		}
+		return ChangePatch
+	}
+
+	switch {
+	case a.pre != b.pre:
+		return ChangePrerelease
+	case a.post != b.post:
+		return ChangePatch
+	case a.build != b.build:
+		return ChangeBuild
+	}
+	return ChangeNone
+}
+
+func releasePart(release []string, i int) string {
+	if i < len(release) {
+		return trimLeadingZeros(release[i])
+	}
+	return "0"
+}
+
+func diffPartsFor(version, scheme string) (diffParts, error) {
+	version = strings.TrimSpace(version)
+	if !validVersionForScheme(version, scheme) {
+		return diffParts{}, fmt.Errorf("invalid %s version: %s", scheme, version)
+	}
+
+	switch scheme {
+	case schemePyPI:
+		return pep440DiffParts(version), nil
+	case schemeComposer:
+		return composerDiffParts(version)
+	case schemeDeb:
+		return debianDiffParts(version), nil
+	case schemeRPM:
+		return rpmDiffParts(version), nil
+	case schemeNuGet:
+		parsed := parseNuGetVersion(version)
+		_, build, _ := strings.Cut(version, "+")
+		return diffParts{release: parsed.numeric[:], pre: strings.ToLower(parsed.prerelease), build: build}, nil
+	}
+	return genericDiffParts(strings.TrimPrefix(version, "v")), nil
+}
+
+// genericDiffParts splits the leading numeric release off a version. What
+// follows is build metadata after a +, and a prerelease otherwise.
+func genericDiffParts(version string) diffParts {
+	release, rest := splitNumericRelease(version)
+	parts := diffParts{release: release}
+	rest, parts.build, _ = strings.Cut(rest, "+")
+	parts.pre = strings.ToLower(rest)
+	return parts
+}
+
+// splitNumericRelease returns the leading dot-separated numbers of version
+// and the text after them.
+func splitNumericRelease(version string) ([]string, string) {
+	var release []string
+	rest := version
+	for {
+		end := 0
+		for end < len(rest) && isASCIIDigit(rest[end]) {
+			end++
+		}
+		if end == 0 {
+			return release, rest
+		}
+		release = append(release, rest[:end])
+		rest = rest[end:]
+		if len(rest) < 2 || rest[0] != '.' || !isASCIIDigit(rest[1]) { //nolint:mnd
+			return release, rest
+		}
+		rest = rest[1:]
+	}
+}
+
+func pep440DiffParts(version string) diffParts {
+	parsed, _ := parsePEP440(version)
+	parts := diffParts{epoch: parsed.epoch, release: parsed.release}
+	if parsed.hasPre {
+		parts.pre = fmt.Sprintf("%d.%s", parsed.preTag, trimLeadingZeros(parsed.preNum))
+	}
+	if parsed.hasDev {
+		parts.pre += ".dev" + trimLeadingZeros(parsed.dev)
+	}
+	if parsed.hasPost {
+		parts.post = trimLeadingZeros(parsed.post)
+	}
+	local := make([]string, len(parsed.local))
+	for i, part := range parsed.local {
+		local[i] = part.s
+	}
+	parts.build = strings.Join(local, ".")
+	return parts
+}
+
+func composerDiffParts(version string) (diffParts, error) {
+	parsed, ok := parseComposerVersion(version)
+	if !ok {
+		return diffParts{}, fmt.Errorf("cannot classify composer branch version: %s", version)
+	}
+	parts := diffParts{release: parsed.core[:]}
+	switch {
+	case parsed.stability == composerStabilityPatch:
+		parts.post = parsed.number
+	case parsed.stability != composerStabilityStable:
+		parts.pre = fmt.Sprintf("%d.%s", parsed.stability, parsed.number)
+	}
+	return parts, nil
+}
+
+// debianDiffParts splits [epoch:]upstream[-revision]. A ~ in the upstream
+// version starts a prerelease, a + a repack, and anything else a patch.
+func debianDiffParts(version string) diffParts {
+	var parts diffParts
+	if epoch, rest, ok := strings.Cut(version, ":"); ok {
+		parts.epoch, version = epoch, rest
+	}
+	if i := strings.LastIndexByte(version, '-'); i >= 0 {
+		parts.build, version = version[i+1:], version[:i]
+	}
+	var rest string
+	parts.release, rest = splitNumericRelease(version)
+	switch {
+	case strings.HasPrefix(rest, "~"):
+		parts.pre = rest
+	case strings.HasPrefix(rest, "+"):
+		parts.build = rest + "-" + parts.build
+	default:
+		parts.post = rest
+	}
+	return parts
+}
+
+// rpmDiffParts splits [epoch:]version[-release]. The release only changes
+// the packaging, so it counts as a build.
+func rpmDiffParts(version string) diffParts {
+	parts := debianDiffParts(version)
+	if strings.HasPrefix(parts.post, "^") {
+		// A ^ snapshot sorts after the release it follows.
+		parts.pre = parts.post
+		parts.post = ""
+	}
+	return parts
+}
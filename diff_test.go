@@ -0,0 +1,89 @@
+package vers
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to, scheme string
+		want             ChangeKind
+	}{
+		{"1.2.3", "2.0.0", "npm", ChangeMajor},
+		{"1.2.3", "1.3.0", "npm", ChangeMinor},
+		{"1.2.3", "1.2.4", "npm", ChangePatch},
+		{"1.2.3-rc.1", "1.2.3", "npm", ChangePrerelease},
+		{"1.2.3+a", "1.2.3+b", "npm", ChangeBuild},
+		{"1.2.3", "1.2.3", "npm", ChangeNone},
+		{"2.0.0", "1.2.3", "npm", ChangeMajor},
+		{"0.2.3", "0.3.0", "npm", ChangeMajor},
+		{"0.2.3", "0.2.4", "npm", ChangePatch},
+		{"0.0.3", "0.0.4", "cargo", ChangeMajor},
+		{"0.2.3", "0.3.0", "semver", ChangeMinor},
+		{"0.1.0", "0.2.0", "pub", ChangeMajor},
+		{"v1.2.3", "v1.3.0", "go", ChangeMinor},
+		{"v1.2.3", "v1.2.3+incompatible", "go", ChangeBuild},
+		{"1.0", "1.1", "pypi", ChangeMinor},
+		{"1.0", "1.0.1", "pypi", ChangePatch},
+		{"1.0", "1!1.0", "pypi", ChangeMajor},
+		{"1.0", "1.0.post1", "pypi", ChangePatch},
+		{"1.0rc1", "1.0", "pypi", ChangePrerelease},
+		{"1.0.dev1", "1.0.dev2", "pypi", ChangePrerelease},
+		{"1.0", "1.0.0", "pypi", ChangeNone},
+		{"1.0+ubuntu1", "1.0+ubuntu2", "pypi", ChangeBuild},
+		{"1.2.3.4", "1.2.3.5", "maven", ChangePatch},
+		{"1.2", "1.2.0", "maven", ChangeNone},
+		{"1.0-SNAPSHOT", "1.0", "maven", ChangePrerelease},
+		{"1.0.0.1", "1.0.0.2", "nuget", ChangePatch},
+		{"1.0.0-beta", "1.0.0-BETA", "nuget", ChangeNone},
+		{"1:1.0-1", "2:1.0-1", "deb", ChangeMajor},
+		{"1.2.3-1", "1.2.3-2", "deb", ChangeBuild},
+		{"1.2.3~rc1-1", "1.2.3-1", "deb", ChangePrerelease},
+		{"1.2.3-1", "1.2.4-1", "debian", ChangePatch},
+		{"1.2.3-1.el8", "1.2.3-2.el8", "rpm", ChangeBuild},
+		{"1.2.3-1", "1.3.0-1", "rpm", ChangeMinor},
+		{"1.2.3.4", "1.3.0.0", "hackage", ChangeMajor},
+		{"1.2.3.4", "1.2.4", "hackage", ChangeMinor},
+		{"1.2.3.4", "1.2.3.5", "hackage", ChangePatch},
+		{"0.3.1", "0.4.0", "composer", ChangeMajor},
+		{"1.0.0-beta2", "1.0.0", "composer", ChangePrerelease},
+		{"1.0.0", "1.0.0-p1", "composer", ChangePatch},
+		{"1.0.0", "1.0.0.pre", "gem", ChangePrerelease},
+	}
+	for _, tt := range tests {
+		got, err := Diff(tt.from, tt.to, tt.scheme)
+		if err != nil {
+			t.Errorf("Diff(%q, %q, %s) error: %v", tt.from, tt.to, tt.scheme, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Diff(%q, %q, %s) = %s, want %s", tt.from, tt.to, tt.scheme, got, tt.want)
+		}
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ from, to, scheme string }{
+		{"not a version", "1.0.0", "npm"},
+		{"1.0", "1.0 beta", "pypi"},
+		{"dev-main", "1.0.0", "composer"},
+	}
+	for _, tt := range tests {
+		if _, err := Diff(tt.from, tt.to, tt.scheme); err == nil {
+			t.Errorf("Diff(%q, %q, %s) expected error", tt.from, tt.to, tt.scheme)
+		}
+	}
+}
+
+func TestChangeKindString(t *testing.T) {
+	t.Parallel()
+
+	if got := ChangeMajor.String(); got != "major" {
+		t.Errorf("ChangeMajor.String() = %q", got)
+	}
+	if got := ChangeKind(42).String(); got != "ChangeKind(42)" {
+		t.Errorf("ChangeKind(42).String() = %q", got)
+	}
+}
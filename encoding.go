@@ -0,0 +1,211 @@
+package vers
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Text and JSON encodings for Range, Interval and Constraint.
+//
+// The text forms are a vers URI for a Range, interval notation such as
+// [1.0,2.0) for an Interval, and an operator and version such as >=1.2.3
+// for a Constraint. JSON encodes each as an object that keeps every field,
+// and decoding accepts either the object or the text form as a string.
+
+type rangeJSON struct {
+	Scheme         string     `json:"scheme,omitempty"`
+	Intervals      []Interval `json:"intervals"`
+	Exclusions     []string   `json:"exclusions,omitempty"`
+	RawConstraints []Interval `json:"raw_constraints,omitempty"`
+}
+
+type intervalJSON struct {
+	Min          string `json:"min,omitempty"`
+	Max          string `json:"max,omitempty"`
+	MinInclusive bool   `json:"min_inclusive"`
+	MaxInclusive bool   `json:"max_inclusive"`
+}
+
+type constraintJSON struct {
+	Operator string `json:"operator"`
+	Version  string `json:"version"`
+	Scheme   string `json:"scheme,omitempty"`
+}
+
+const (
+	infNegative = "-inf"
+	infPositive = "+inf"
+)
+
+// isJSONString reports whether data holds a JSON string rather than an object.
+func isJSONString(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '"'
+}
+
+// MarshalText encodes the range as a vers URI. A range needs a scheme to
+// be written as a vers URI.
+func (r *Range) MarshalText() ([]byte, error) {
+	if r.Scheme == "" {
+		return nil, fmt.Errorf("cannot encode a range without a scheme as a vers URI")
+	}
+	return []byte(ToVersString(r, r.Scheme)), nil
+}
+
+// UnmarshalText decodes a vers URI.
+func (r *Range) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
+
+// MarshalJSON encodes the range as an object with its scheme, intervals,
+// exclusions and raw constraints. It has a value receiver so that ranges
+// held by value, in structs, slices and maps, encode the same way.
+func (r Range) MarshalJSON() ([]byte, error) {
+	intervals := r.Intervals
+	if intervals == nil {
+		intervals = []Interval{}
+	}
+	return json.Marshal(rangeJSON{
+		Scheme:         r.Scheme,
+		Intervals:      intervals,
+		Exclusions:     r.Exclusions,
+		RawConstraints: r.RawConstraints,
+	})
+}
+
+// UnmarshalJSON decodes either a vers URI string or the object written by
+// MarshalJSON.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(text))
+	}
+	var decoded rangeJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = Range{
+		Intervals:      decoded.Intervals,
+		Exclusions:     decoded.Exclusions,
+		RawConstraints: decoded.RawConstraints,
+		Scheme:         decoded.Scheme,
+	}
+	return nil
+}
+
+// MarshalText encodes the interval in interval notation, such as [1.0,2.0)
+// or (-inf,2.0]. The brackets always reflect the inclusive flags, so the
+// text decodes to the same interval.
+func (i Interval) MarshalText() ([]byte, error) {
+	if strings.Contains(i.Min, ",") || strings.Contains(i.Max, ",") {
+		return nil, fmt.Errorf("cannot encode interval bound containing a comma: %s,%s", i.Min, i.Max)
+	}
+	minBracket, maxBracket := "(", ")"
+	if i.MinInclusive {
+		minBracket = "["
+	}
+	if i.MaxInclusive {
+		maxBracket = "]"
+	}
+	minStr, maxStr := cmp.Or(i.Min, infNegative), cmp.Or(i.Max, infPositive)
+	return []byte(minBracket + minStr + "," + maxStr + maxBracket), nil
+}
+
+// UnmarshalText decodes interval notation written by MarshalText.
+func (i *Interval) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[len(s)-1:], "])") { //nolint:mnd
+		return fmt.Errorf("invalid interval: %s", s)
+	}
+	lower, upper, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok || strings.Contains(upper, ",") {
+		return fmt.Errorf("invalid interval: %s", s)
+	}
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	if lower == infNegative {
+		lower = ""
+	}
+	if upper == infPositive {
+		upper = ""
+	}
+	*i = NewInterval(lower, upper, s[0] == '[', s[len(s)-1] == ']')
+	return nil
+}
+
+// MarshalJSON encodes the interval as an object with its bounds and flags.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(intervalJSON(i))
+}
+
+// UnmarshalJSON decodes either interval notation or the object written by
+// MarshalJSON.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return i.UnmarshalText([]byte(text))
+	}
+	var decoded intervalJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*i = Interval(decoded)
+	return nil
+}
+
+// MarshalText encodes the constraint as its operator and version.
+func (c *Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes an operator and version, keeping the receiver's
+// scheme for scheme-specific parsing.
+func (c *Constraint) UnmarshalText(text []byte) error {
+	parsed, err := parseConstraintWithScheme(string(text), c.Scheme)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// MarshalJSON encodes the constraint as an object with its operator,
+// version and scheme. Like Range's, it has a value receiver.
+func (c Constraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(constraintJSON(c))
+}
+
+// UnmarshalJSON decodes either a constraint string or the object written
+// by MarshalJSON.
+func (c *Constraint) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return c.UnmarshalText([]byte(text))
+	}
+	var decoded constraintJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if !slices.Contains(ValidOperators, decoded.Operator) || decoded.Version == "" {
+		return fmt.Errorf("invalid constraint: %s%s", decoded.Operator, decoded.Version)
+	}
+	*c = Constraint(decoded)
+	return nil
+}
@@ -0,0 +1,239 @@
+package vers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRangeTextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, uri := range []string{
+		"vers:npm/>=1.0.0|<2.0.0",
+		"vers:pypi/>=1.0|!=1.5|<2.0",
+		"vers:maven/1.0|>=2.0",
+		"vers:golang/>=v1.2.0",
+		"vers:deb/*",
+	} {
+		r, err := Parse(uri)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", uri, err)
+		}
+		text, err := r.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%q) error: %v", uri, err)
+		}
+		if string(text) != uri {
+			t.Errorf("MarshalText(%q) = %q", uri, text)
+		}
+		var decoded Range
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) error: %v", text, err)
+		}
+		if !reflect.DeepEqual(&decoded, r) {
+			t.Errorf("text round trip of %q = %+v, want %+v", uri, decoded, *r)
+		}
+	}
+
+	if _, err := Exact("1.0").MarshalText(); err == nil {
+		t.Error("MarshalText of a range without a scheme expected error")
+	}
+	var r Range
+	if err := r.UnmarshalText([]byte(">=1.0")); err == nil {
+		t.Error("UnmarshalText of a non-vers string expected error")
+	}
+}
+
+func TestRangeJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseNative("^1.2.3 || >=3.0.0", "npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = r.Exclude("1.5.0")
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded *Range
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(%s) error: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Errorf("JSON round trip = %+v, want %+v", *decoded, *r)
+	}
+	if !decoded.Contains("1.4.0") || decoded.Contains("1.5.0") || decoded.Contains("2.0.0-rc.1") {
+		t.Errorf("decoded range lost its npm semantics: %s", data)
+	}
+}
+
+func TestRangeJSONFormats(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(NewRange([]Interval{NewInterval("1.0", "2.0", true, false)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"intervals":[{"min":"1.0","max":"2.0","min_inclusive":true,"max_inclusive":false}]}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var payload struct {
+		Affected *Range `json:"affected"`
+		Fixed    *Range `json:"fixed"`
+	}
+	input := `{
+		"affected": "vers:npm/>=1.0.0|<1.2.5",
+		"fixed": {"scheme": "npm", "intervals": ["[1.2.5,+inf)"], "exclusions": ["1.3.0"]}
+	}`
+	if err := json.Unmarshal([]byte(input), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Affected.Scheme != "npm" || !payload.Affected.Contains("1.2.4") || payload.Affected.Contains("1.2.5") {
+		t.Errorf("affected = %+v", payload.Affected)
+	}
+	if payload.Fixed.Scheme != "npm" || !payload.Fixed.Contains("1.2.5") || payload.Fixed.Contains("1.3.0") {
+		t.Errorf("fixed = %+v", payload.Fixed)
+	}
+
+	for _, bad := range []string{`"npm/>=1.0"`, `{"intervals": ["1.0,2.0"]}`, `{"intervals": 1}`} {
+		var r Range
+		if err := json.Unmarshal([]byte(bad), &r); err == nil {
+			t.Errorf("Unmarshal(%s) expected error", bad)
+		}
+	}
+}
+
+func TestIntervalEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		interval Interval
+		text     string
+	}{
+		{NewInterval("1.0", "2.0", true, false), "[1.0,2.0)"},
+		{GreaterThanInterval("1.0", false), "(1.0,+inf)"},
+		{LessThanInterval("2.0", true), "(-inf,2.0]"},
+		{UnboundedInterval(), "(-inf,+inf)"},
+		{ExactInterval("1.5"), "[1.5,1.5]"},
+		{EmptyInterval(), "[1,0]"},
+	}
+	for _, tt := range tests {
+		text, err := tt.interval.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != tt.text {
+			t.Errorf("MarshalText(%+v) = %s, want %s", tt.interval, text, tt.text)
+		}
+		var decoded Interval
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if decoded != tt.interval {
+			t.Errorf("UnmarshalText(%s) = %+v, want %+v", text, decoded, tt.interval)
+		}
+
+		data, err := json.Marshal(tt.interval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded = Interval{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded != tt.interval {
+			t.Errorf("JSON round trip of %+v = %+v", tt.interval, decoded)
+		}
+	}
+
+	if _, err := NewInterval("1,0", "", true, false).MarshalText(); err == nil {
+		t.Error("MarshalText of a bound with a comma expected error")
+	}
+	for _, bad := range []string{"", "1.0,2.0", "[1.0]", "[1.0,2.0,3.0)", "{1.0,2.0}"} {
+		var i Interval
+		if err := i.UnmarshalText([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalText(%q) expected error", bad)
+		}
+	}
+}
+
+func TestConstraintEncoding(t *testing.T) {
+	t.Parallel()
+
+	c := &Constraint{Operator: ">=", Version: "v1.2.0", Scheme: "golang"}
+	text, err := c.MarshalText()
+	if err != nil || string(text) != ">=v1.2.0" {
+		t.Errorf("MarshalText = %q, %v", text, err)
+	}
+	decoded := &Constraint{Scheme: "golang"}
+	if err := decoded.UnmarshalText(text); err != nil || *decoded != *c {
+		t.Errorf("UnmarshalText(%s) = %+v, %v; want %+v", text, decoded, err, c)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"operator":"\u003e=","version":"v1.2.0","scheme":"golang"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	decoded = &Constraint{}
+	if err := json.Unmarshal(data, decoded); err != nil || *decoded != *c {
+		t.Errorf("JSON round trip = %+v, %v; want %+v", decoded, err, c)
+	}
+
+	decoded = &Constraint{}
+	if err := json.Unmarshal([]byte(`"!=1.5"`), decoded); err != nil || decoded.Operator != "!=" || decoded.Version != "1.5" {
+		t.Errorf("Unmarshal string = %+v, %v", decoded, err)
+	}
+	for _, bad := range []string{`""`, `{"operator":"~>","version":"1.0"}`, `{"operator":">="}`} {
+		if err := json.Unmarshal([]byte(bad), &Constraint{}); err == nil {
+			t.Errorf("Unmarshal(%s) expected error", bad)
+		} else if strings.TrimSpace(err.Error()) == "" {
+			t.Errorf("Unmarshal(%s) returned an empty error", bad)
+		}
+	}
+}
+
+func TestEncodingValueFields(t *testing.T) {
+	t.Parallel()
+
+	type advisory struct {
+		Affected   Range                 `json:"affected"`
+		Fixed      []Range               `json:"fixed"`
+		Constraint Constraint            `json:"constraint"`
+		ByName     map[string]Constraint `json:"by_name"`
+	}
+	r, err := Parse("vers:npm/>=1.0.0|<2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Constraint{Operator: ">=", Version: "1.0.0", Scheme: "npm"}
+	value := advisory{Affected: *r, Fixed: []Range{*r}, Constraint: c, ByName: map[string]Constraint{"a": c}}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rangeData, _ := json.Marshal(r)
+	constraintData, _ := json.Marshal(&c)
+	want := `{"affected":` + string(rangeData) + `,"fixed":[` + string(rangeData) + `],"constraint":` +
+		string(constraintData) + `,"by_name":{"a":` + string(constraintData) + `}}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var decoded advisory
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Errorf("JSON round trip = %+v, want %+v", decoded, value)
+	}
+}
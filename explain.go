@@ -0,0 +1,195 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplanationRule names a scheme-specific rule that decided whether a
+// version satisfies a range, beyond comparing it with interval bounds.
+type ExplanationRule string
+
+const (
+	// RuleInvalidVersion rejects a version the scheme cannot parse.
+	RuleInvalidVersion ExplanationRule = "invalid-version"
+	// RuleSemverPrerelease is the npm and cargo rule that a prerelease only
+	// matches an interval with a prerelease bound on the same major.minor.patch.
+	RuleSemverPrerelease ExplanationRule = "semver-prerelease"
+	// RuleTerraformPrerelease is the go-version rule that a prerelease only
+	// matches a constraint naming a prerelease of the same version.
+	RuleTerraformPrerelease ExplanationRule = "terraform-prerelease"
+	// RulePEP440Equality compares versions the way PEP 440 == does: releases
+	// are zero-padded and local labels are ignored unless the specifier has one.
+	RulePEP440Equality ExplanationRule = "pep440-equality"
+	// RulePEP440PostRelease excludes post-releases of an exclusive minimum.
+	RulePEP440PostRelease ExplanationRule = "pep440-post-release"
+	// RulePEP440PreRelease excludes pre-releases of an exclusive maximum.
+	RulePEP440PreRelease ExplanationRule = "pep440-pre-release"
+	// RuleComposerBranch matches Composer branch versions such as dev-main
+	// only against * or the same branch.
+	RuleComposerBranch ExplanationRule = "composer-branch"
+)
+
+// ExplanationBound identifies the interval bound a version fell outside.
+type ExplanationBound string
+
+const (
+	// BoundMinimum means the version fell outside the lower bound.
+	BoundMinimum ExplanationBound = "minimum"
+	// BoundMaximum means the version fell outside the upper bound.
+	BoundMaximum ExplanationBound = "maximum"
+)
+
+// Explanation reports why a version does or does not satisfy a range.
+type Explanation struct {
+	Version   string
+	Range     string
+	Scheme    string
+	Satisfied bool
+	// Exclusion is the != version that rejected the version.
+	Exclusion string
+	// Matched is the index of the interval that contains the version, or -1.
+	Matched int
+	// Intervals explains each interval checked, in order, up to the match.
+	Intervals []IntervalExplanation
+	// Rule is the scheme rule that decided the result, if any.
+	Rule ExplanationRule
+	// Message is the explanation as a sentence.
+	Message string
+}
+
+// IntervalExplanation reports how a version compared with one interval.
+type IntervalExplanation struct {
+	Interval Interval
+	Contains bool
+	// FailedBound is the bound the version fell outside, if any.
+	FailedBound ExplanationBound
+	// Rule is the scheme rule that decided the result, if any.
+	Rule ExplanationRule
+}
+
+// String returns the explanation as a sentence.
+func (e Explanation) String() string {
+	return e.Message
+}
+
+// Explain reports why version does or does not satisfy the range. It
+// applies the same rules as Contains, so Satisfied always equals
+// r.Contains(version).
+func (r *Range) Explain(version string) Explanation {
+	scheme, cmp := r.containsScheme()
+	e := Explanation{Version: version, Range: r.String(), Scheme: r.Scheme, Matched: -1}
+
+	if !schemeAcceptsVersion(scheme, version) {
+		e.Rule = RuleInvalidVersion
+		e.Message = fmt.Sprintf("%s does not satisfy %s: it is not a valid %s version", version, e.Range, scheme)
+		return e
+	}
+
+	if exc, rule, excluded := excludedBy(r.Exclusions, version, scheme, cmp); excluded {
+		e.Exclusion = exc
+		e.Rule = rule
+		e.Message = fmt.Sprintf("%s does not satisfy %s: it is excluded by !=%s", version, e.Range, exc)
+		return e
+	}
+
+	for i, interval := range r.Intervals {
+		contains, rule := intervalContains(interval, version, scheme, cmp)
+		detail := IntervalExplanation{Interval: interval, Contains: contains, Rule: rule}
+		if !contains && rule == "" {
+			detail.FailedBound = failedBound(interval, version, cmp)
+		}
+		e.Intervals = append(e.Intervals, detail)
+		if contains {
+			e.Satisfied = true
+			e.Matched = i
+			e.Rule = rule
+			e.Message = fmt.Sprintf("%s satisfies %s: it is within %s", version, e.Range, interval.stringCmp(cmp))
+			if rule != "" {
+				e.Message += " " + matchedRuleNote(rule)
+			}
+			return e
+		}
+	}
+
+	switch len(e.Intervals) {
+	case 0:
+		e.Message = fmt.Sprintf("%s does not satisfy %s: the range matches no versions", version, e.Range)
+	case 1:
+		e.Rule = e.Intervals[0].Rule
+		e.Message = fmt.Sprintf("%s does not satisfy %s: %s", version, e.Range, intervalReason(e.Intervals[0], scheme, cmp))
+	default:
+		reasons := make([]string, len(e.Intervals))
+		for i, detail := range e.Intervals {
+			reasons[i] = fmt.Sprintf("%s (%s)", detail.Interval.stringCmp(cmp), intervalReason(detail, scheme, cmp))
+		}
+		e.Message = fmt.Sprintf("%s does not satisfy %s: it is outside every interval: %s", version, e.Range, strings.Join(reasons, "; "))
+	}
+	return e
+}
+
+// failedBound returns the bound of interval that version falls outside.
+func failedBound(interval Interval, version string, cmp func(a, b string) int) ExplanationBound {
+	if interval.Min != "" {
+		c := cmp(version, interval.Min)
+		if c < 0 || (c == 0 && !interval.MinInclusive) {
+			return BoundMinimum
+		}
+	}
+	if interval.Max != "" {
+		c := cmp(version, interval.Max)
+		if c > 0 || (c == 0 && !interval.MaxInclusive) {
+			return BoundMaximum
+		}
+	}
+	return ""
+}
+
+func intervalReason(detail IntervalExplanation, scheme string, cmp func(a, b string) int) string {
+	interval := detail.Interval
+	switch detail.FailedBound {
+	case BoundMinimum:
+		if interval.MinInclusive {
+			return "it is below the minimum " + interval.Min
+		}
+		return "it is not above the exclusive minimum " + interval.Min
+	case BoundMaximum:
+		if interval.MaxInclusive {
+			return "it is above the maximum " + interval.Max
+		}
+		return "it is not below the exclusive maximum " + interval.Max
+	}
+
+	switch detail.Rule {
+	case RuleInvalidVersion:
+		return fmt.Sprintf("it is not a valid %s version", scheme)
+	case RuleSemverPrerelease:
+		return fmt.Sprintf("it is a prerelease, and %s only matches a prerelease when a bound of %s is a prerelease of the same major.minor.patch",
+			scheme, interval.stringCmp(cmp))
+	case RuleTerraformPrerelease:
+		return fmt.Sprintf("it is a prerelease, and %s only matches a prerelease when the bounds of %s name a prerelease of the same version",
+			scheme, interval.stringCmp(cmp))
+	case RulePEP440Equality:
+		if interval.MinInclusive && interval.MaxInclusive && interval.Min == interval.Max {
+			return "it is not equal to " + interval.Min + " under PEP 440 equality"
+		}
+		return "it equals the exclusive minimum " + interval.Min + " under PEP 440 equality"
+	case RulePEP440PostRelease:
+		return "it is a post-release of the exclusive minimum " + interval.Min + ", which PEP 440 excludes"
+	case RulePEP440PreRelease:
+		return "it is a pre-release of the exclusive maximum " + interval.Max + ", which PEP 440 excludes"
+	case RuleComposerBranch:
+		return "Composer branch versions only match * or the same branch"
+	}
+	return "it is outside " + interval.stringCmp(cmp)
+}
+
+func matchedRuleNote(rule ExplanationRule) string {
+	switch rule {
+	case RulePEP440Equality:
+		return "(PEP 440 equality pads releases with zeros and ignores local labels)"
+	case RuleComposerBranch:
+		return "(as a Composer branch)"
+	}
+	return "(" + string(rule) + ")"
+}
@@ -0,0 +1,78 @@
+package vers
+
+import "testing"
+
+func TestRangeExplain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint, scheme, version string
+		satisfied                   bool
+		rule                        ExplanationRule
+		bound                       ExplanationBound
+		message                     string
+	}{
+		{"^1.0.0", "npm", "1.5.0", true, "", "", "1.5.0 satisfies [1.0.0,2.0.0): it is within [1.0.0,2.0.0)"},
+		{"^1.0.0", "npm", "1.5.0-beta", false, RuleSemverPrerelease, "",
+			"1.5.0-beta does not satisfy [1.0.0,2.0.0): it is a prerelease, and npm only matches a prerelease when a bound of [1.0.0,2.0.0) is a prerelease of the same major.minor.patch"},
+		{"^1.0.0", "npm", "2.0.0", false, "", BoundMaximum,
+			"2.0.0 does not satisfy [1.0.0,2.0.0): it is not below the exclusive maximum 2.0.0"},
+		{"^1.0.0", "npm", "0.9.0", false, "", BoundMinimum,
+			"0.9.0 does not satisfy [1.0.0,2.0.0): it is below the minimum 1.0.0"},
+		{"^1.0.0", "npm", "foo", false, RuleInvalidVersion, "", "foo does not satisfy [1.0.0,2.0.0): it is not a valid npm version"},
+		{">1.0", "pypi", "1.0.post1", false, RulePEP440PostRelease, "",
+			"1.0.post1 does not satisfy (1.0,+inf): it is a post-release of the exclusive minimum 1.0, which PEP 440 excludes"},
+		{"<2.0", "pypi", "2.0rc1", false, RulePEP440PreRelease, "",
+			"2.0rc1 does not satisfy (-inf,2.0): it is a pre-release of the exclusive maximum 2.0, which PEP 440 excludes"},
+		{"==1.0", "pypi", "1.0.0+local", true, RulePEP440Equality, "",
+			"1.0.0+local satisfies [1.0,1.0]: it is within [1.0,1.0] (PEP 440 equality pads releases with zeros and ignores local labels)"},
+		{"^1.0", "composer", "dev-main", false, RuleComposerBranch, "",
+			"dev-main does not satisfy [1.0.0-dev,2.0.0-dev): Composer branch versions only match * or the same branch"},
+		{"dev-main", "composer", "dev-main", true, RuleComposerBranch, "",
+			"dev-main satisfies [dev-main,dev-main]: it is within [dev-main,dev-main] (as a Composer branch)"},
+		{"~> 1.0", "terraform", "1.1.0-beta", false, RuleTerraformPrerelease, "",
+			"1.1.0-beta does not satisfy [1.0,2): it is a prerelease, and terraform only matches a prerelease when the bounds of [1.0,2) name a prerelease of the same version"},
+		{"^1.0.0 || ^3.0.0", "npm", "2.0.0", false, "", "",
+			"2.0.0 does not satisfy [1.0.0,2.0.0) | [3.0.0,4.0.0): it is outside every interval: [1.0.0,2.0.0) (it is not below the exclusive maximum 2.0.0); [3.0.0,4.0.0) (it is below the minimum 3.0.0)"},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, tt.scheme)
+		if err != nil {
+			t.Fatalf("ParseNative(%q, %s) error: %v", tt.constraint, tt.scheme, err)
+		}
+		e := r.Explain(tt.version)
+		if e.Satisfied != tt.satisfied || e.Satisfied != r.Contains(tt.version) {
+			t.Errorf("Explain(%q) on %q: Satisfied = %v, want %v", tt.version, tt.constraint, e.Satisfied, tt.satisfied)
+		}
+		if e.Rule != tt.rule {
+			t.Errorf("Explain(%q) on %q: Rule = %q, want %q", tt.version, tt.constraint, e.Rule, tt.rule)
+		}
+		if len(e.Intervals) == 1 && e.Intervals[0].FailedBound != tt.bound {
+			t.Errorf("Explain(%q) on %q: FailedBound = %q, want %q", tt.version, tt.constraint, e.Intervals[0].FailedBound, tt.bound)
+		}
+		if e.String() != tt.message {
+			t.Errorf("Explain(%q) on %q:\n got %s\nwant %s", tt.version, tt.constraint, e, tt.message)
+		}
+	}
+}
+
+func TestRangeExplainExclusion(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseNative(">=1.0,!=1.5", "pypi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := r.Explain("1.5.0")
+	if e.Satisfied || e.Exclusion != "1.5" || e.Rule != RulePEP440Equality || e.Matched != -1 {
+		t.Errorf("Explain(1.5.0) = %+v, want exclusion 1.5 under PEP 440 equality", e)
+	}
+	if want := "1.5.0 does not satisfy [1.0,+inf) excluding 1.5: it is excluded by !=1.5"; e.Message != want {
+		t.Errorf("Message = %q, want %q", e.Message, want)
+	}
+
+	e = r.Explain("1.6")
+	if !e.Satisfied || e.Matched != 0 {
+		t.Errorf("Explain(1.6) = %+v, want a match on interval 0", e)
+	}
+}
@@ -0,0 +1,83 @@
+package vers
+
+import "sort"
+
+// FixScope limits how far RecommendFix may move from the current version.
+type FixScope int
+
+const (
+	// FixScopeAny allows any newer version.
+	FixScopeAny FixScope = iota
+	// FixScopeMinor allows newer versions with the same major version.
+	FixScopeMinor
+	// FixScopePatch allows newer versions with the same major and minor version.
+	FixScopePatch
+)
+
+// FixPolicy selects which versions RecommendFix may recommend.
+type FixPolicy struct {
+	Scope FixScope
+	// StableOnly skips prereleases, as judged by the range's scheme.
+	StableOnly bool
+}
+
+// FixRecommendation is the result of RecommendFix.
+type FixRecommendation struct {
+	// Version is the smallest upgrade that leaves the vulnerable range, or
+	// empty when no available version qualifies.
+	Version string
+	// Candidates lists every qualifying upgrade, lowest first.
+	Candidates []string
+	// Vulnerable reports whether the current version is in the vulnerable range.
+	Vulnerable bool
+}
+
+// RecommendFix finds upgrades from current that leave the vulnerable range.
+// Candidates come from available, must be newer than current under the
+// range's scheme, and must satisfy the policy's scope and stability.
+//
+// An empty Version means no version in the list fixes the vulnerability
+// under the policy.
+func RecommendFix(current string, vulnerable *Range, available []string, policy FixPolicy) FixRecommendation {
+	scheme := vulnerable.Scheme
+	recommendation := FixRecommendation{Vulnerable: vulnerable.Contains(current)}
+
+	currentParts, currentErr := diffPartsFor(current, canonicalScheme(scheme))
+	for _, candidate := range available {
+		if CompareWithScheme(candidate, current, scheme) <= 0 || vulnerable.Contains(candidate) {
+			continue
+		}
+		if policy.StableOnly && isPrereleaseForScheme(candidate, scheme) {
+			continue
+		}
+		if policy.Scope != FixScopeAny && !sameReleaseLine(currentParts, currentErr, candidate, scheme, policy.Scope) {
+			continue
+		}
+		recommendation.Candidates = append(recommendation.Candidates, candidate)
+	}
+
+	sort.SliceStable(recommendation.Candidates, func(i, j int) bool {
+		return CompareWithScheme(recommendation.Candidates[i], recommendation.Candidates[j], scheme) < 0
+	})
+	if len(recommendation.Candidates) > 0 {
+		recommendation.Version = recommendation.Candidates[0]
+	}
+	return recommendation
+}
+
+// sameReleaseLine reports whether candidate shares current's epoch and major
+// version, and for FixScopePatch its minor version too. Versions are split
+// as Diff splits them, so a deb or PEP 440 epoch change leaves every scope.
+func sameReleaseLine(current diffParts, currentErr error, candidate, scheme string, scope FixScope) bool {
+	if currentErr != nil {
+		return false
+	}
+	parts, err := diffPartsFor(candidate, canonicalScheme(scheme))
+	if err != nil || cmpNumStr(parts.epoch, current.epoch) != 0 {
+		return false
+	}
+	if cmpNumStr(releasePart(parts.release, 0), releasePart(current.release, 0)) != 0 {
+		return false
+	}
+	return scope != FixScopePatch || cmpNumStr(releasePart(parts.release, 1), releasePart(current.release, 1)) == 0
+}
@@ -0,0 +1,88 @@
+package vers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecommendFix(t *testing.T) {
+	t.Parallel()
+
+	vulnerable, err := Parse("vers:npm/>=1.0.0|<1.2.5|>=2.0.0|<2.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	available := []string{"2.1.0", "1.2.4", "1.3.0-rc.1", "1.2.5", "1.3.0", "2.0.5", "3.0.0", "1.2.6", "0.9.0"}
+
+	tests := []struct {
+		name       string
+		current    string
+		policy     FixPolicy
+		want       string
+		candidates []string
+	}{
+		{"any", "1.2.0", FixPolicy{}, "1.2.5", []string{"1.2.5", "1.2.6", "1.3.0-rc.1", "1.3.0", "2.1.0", "3.0.0"}},
+		{"stable only", "1.2.0", FixPolicy{StableOnly: true}, "1.2.5", []string{"1.2.5", "1.2.6", "1.3.0", "2.1.0", "3.0.0"}},
+		{"minor", "1.2.0", FixPolicy{Scope: FixScopeMinor, StableOnly: true}, "1.2.5", []string{"1.2.5", "1.2.6", "1.3.0"}},
+		{"patch", "1.2.0", FixPolicy{Scope: FixScopePatch}, "1.2.5", []string{"1.2.5", "1.2.6"}},
+		{"skips vulnerable line", "2.0.1", FixPolicy{Scope: FixScopeMinor}, "2.1.0", []string{"2.1.0"}},
+		{"no fix in line", "2.0.1", FixPolicy{Scope: FixScopePatch}, "", nil},
+	}
+	for _, tt := range tests {
+		got := RecommendFix(tt.current, vulnerable, available, tt.policy)
+		if got.Version != tt.want {
+			t.Errorf("%s: Version = %q, want %q", tt.name, got.Version, tt.want)
+		}
+		if !reflect.DeepEqual(got.Candidates, tt.candidates) {
+			t.Errorf("%s: Candidates = %v, want %v", tt.name, got.Candidates, tt.candidates)
+		}
+		if !got.Vulnerable {
+			t.Errorf("%s: Vulnerable = false, want true", tt.name)
+		}
+	}
+}
+
+func TestRecommendFixUsesScheme(t *testing.T) {
+	t.Parallel()
+
+	vulnerable, err := ParseNative("<1.0.post1", "pypi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := RecommendFix("1.0", vulnerable, []string{"1.1rc1", "1.0.post1", "1.1"}, FixPolicy{StableOnly: true})
+	if got.Version != "1.0.post1" || !reflect.DeepEqual(got.Candidates, []string{"1.0.post1", "1.1"}) {
+		t.Errorf("RecommendFix = %+v, want 1.0.post1 then 1.1", got)
+	}
+
+	got = RecommendFix("2.0", vulnerable, []string{"1.1"}, FixPolicy{})
+	if got.Vulnerable || got.Version != "" {
+		t.Errorf("RecommendFix for an unaffected version = %+v, want no candidates and not vulnerable", got)
+	}
+}
+
+func TestRecommendFixScopeKeepsEpoch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		scheme    string
+		current   string
+		scope     FixScope
+		available []string
+		want      []string
+	}{
+		{"deb minor", "deb", "1:2.3-1", FixScopeMinor, []string{"1:3.0-1", "2:0.1-1", "2.4-1", "1:2.4-1", "1:2.3-2"}, []string{"1:2.3-2", "1:2.4-1"}},
+		{"deb patch", "deb", "1:2.3-1", FixScopePatch, []string{"1:2.4-1", "1:2.3.1-1", "2:2.3.1-1"}, []string{"1:2.3.1-1"}},
+		{"deb zero epoch", "deb", "0:2.3-1", FixScopeMinor, []string{"2.4-1"}, []string{"2.4-1"}},
+		{"rpm minor", "rpm", "1:2.3-1.el9", FixScopeMinor, []string{"2:2.4-1.el9", "1:2.4-1.el9", "1:3.0-1.el9"}, []string{"1:2.4-1.el9"}},
+		{"pypi minor", "pypi", "1!1.0.1", FixScopeMinor, []string{"1!2.0", "2!0.0.1", "1!1.1", "1.2"}, []string{"1!1.1"}},
+		{"pypi patch", "pypi", "1!1.0.1", FixScopePatch, []string{"1!1.1", "1!1.0.2", "2!1.0.2"}, []string{"1!1.0.2"}},
+	}
+	for _, tt := range tests {
+		vulnerable := rangeWithScheme(Empty(), tt.scheme)
+		got := RecommendFix(tt.current, vulnerable, tt.available, FixPolicy{Scope: tt.scope})
+		if !reflect.DeepEqual(got.Candidates, tt.want) {
+			t.Errorf("%s: Candidates = %v, want %v", tt.name, got.Candidates, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// gemRequirementRegex matches one requirement as Gem::Requirement::PATTERN
+// does: an optional operator and a Gem::Version::VERSION_PATTERN version.
+var gemRequirementRegex = regexp.MustCompile(`^\s*(=|!=|>=|<=|>|<|~>)?\s*([0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)\s*$`)
+
+// GemConstraint is one operator and version of a gem requirement, such as
+// ~> 7.0. Version is normalized as Gem::Version does, so 1.0-beta becomes
+// 1.0.pre.beta.
+type GemConstraint struct {
+	Op      string
+	Version string
+}
+
+// GemRequirement is a gem dependency requirement as Gem::Requirement holds
+// it, such as the "~> 7.0", ">= 7.0.4" of gem "rails", "~> 7.0", ">= 7.0.4".
+type GemRequirement struct {
+	Constraints []GemConstraint
+}
+
+// ParseGemRequirement parses the requirement strings of a Gemfile gem line
+// or a gemspec add_dependency call, as Gem::Requirement.new does. Each
+// string holds one requirement, and a bare version means =. Duplicate
+// strings are dropped, and no requirements at all means the default >= 0.
+func ParseGemRequirement(requirements []string) (*GemRequirement, error) {
+	var unique []string
+	for _, requirement := range requirements {
+		if !slices.Contains(unique, requirement) {
+			unique = append(unique, requirement)
+		}
+	}
+	if len(unique) == 0 {
+		return &GemRequirement{Constraints: []GemConstraint{{Op: ">=", Version: "0"}}}, nil
+	}
+
+	req := &GemRequirement{Constraints: make([]GemConstraint, 0, len(unique))}
+	for _, requirement := range unique {
+		match := gemRequirementRegex.FindStringSubmatch(requirement)
+		if match == nil {
+			return nil, fmt.Errorf("invalid gem requirement: %q", requirement)
+		}
+		op := match[1]
+		if op == "" {
+			op = "="
+		}
+		version := strings.ReplaceAll(match[2], "-", ".pre.")
+		req.Constraints = append(req.Constraints, GemConstraint{Op: op, Version: version})
+	}
+	return req, nil
+}
+
+// String returns the requirement as Gem::Requirement#to_s prints it: each
+// constraint as "op version", in the order given, joined by ", ".
+func (r *GemRequirement) String() string {
+	parts := make([]string, len(r.Constraints))
+	for i, c := range r.Constraints {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String returns the constraint as "op version".
+func (c GemConstraint) String() string {
+	return c.Op + " " + c.Version
+}
+
+// IsPrerelease reports whether any constraint names a prerelease version,
+// which lets the requirement match prereleases.
+func (r *GemRequirement) IsPrerelease() bool {
+	for _, c := range r.Constraints {
+		if isGemPrerelease(c.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// SatisfiedBy reports whether version satisfies every constraint, as
+// Gem::Requirement#satisfied_by? does. It does not apply the prerelease
+// rule; use Matches to choose among released gems. As in Gem::Version, a
+// blank version is 0, and other invalid versions never satisfy the
+// requirement.
+func (r *GemRequirement) SatisfiedBy(version string) bool {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		version = "0"
+	}
+	if !validVersionForScheme(version, schemeGem) {
+		return false
+	}
+	for _, c := range r.Constraints {
+		if !c.satisfiedBy(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether RubyGems and Bundler would pick version for the
+// requirement, as Gem::Dependency#match? does: the default >= 0 matches
+// any version, and otherwise a prerelease version only matches when the
+// requirement itself names a prerelease, so ~> 1.0 never matches 1.1.0.rc1
+// but ~> 1.1.a does.
+func (r *GemRequirement) Matches(version string) bool {
+	if r.isDefault() {
+		return true
+	}
+	if isGemPrerelease(version) && !r.IsPrerelease() {
+		return false
+	}
+	return r.SatisfiedBy(version)
+}
+
+// isDefault reports whether the requirement is the default >= 0, as
+// Gem::Requirement#none? does.
+func (r *GemRequirement) isDefault() bool {
+	return len(r.Constraints) == 1 && r.Constraints[0] == GemConstraint{Op: ">=", Version: "0"}
+}
+
+// satisfiedBy applies the constraint's operator as Gem::Requirement::OPS
+// does. ~> v admits versions from v whose release is below v's bump.
+func (c GemConstraint) satisfiedBy(version string) bool {
+	cmp := compareGem(version, c.Version)
+	switch c.Op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		return cmp >= 0 && compareGem(gemRelease(version), gemBump(c.Version)) < 0
+	}
+	return false
+}
+
+// isGemPrerelease reports whether a version contains a letter, as
+// Gem::Version#prerelease? does. A - counts too, since Gem::Version reads
+// it as .pre.
+func isGemPrerelease(version string) bool {
+	return strings.ContainsFunc(version, func(r rune) bool {
+		return r == '-' || r < 0x80 && isASCIIAlpha(byte(r))
+	})
+}
+
+// gemRelease returns the numeric segments before the first letter, as
+// Gem::Version#release does.
+func gemRelease(version string) string {
+	var release []string
+	for _, segment := range parseGemRawSegments(version) {
+		if !segment.num {
+			break
+		}
+		release = append(release, segment.value)
+	}
+	return strings.Join(release, ".")
+}
+
+// gemBump returns the version ~> rounds up to, as Gem::Version#bump does:
+// 1.4.4 bumps to 1.5, 1.0 to 2 and 5.a to 6.
+func gemBump(version string) string {
+	release := strings.Split(gemRelease(version), ".")
+	if len(release) > 1 {
+		release = release[:len(release)-1]
+	}
+	release[len(release)-1] = incNumStr(release[len(release)-1])
+	return strings.Join(release, ".")
+}
+
+// Range returns the requirement as a *Range using the gem scheme. The range's
+// Contains follows SatisfiedBy, without the prerelease rule.
+func (r *GemRequirement) Range() *Range {
+	parts := make([]string, len(r.Constraints))
+	for i, c := range r.Constraints {
+		parts[i] = c.String()
+	}
+	result, err := ParseNative(strings.Join(parts, ", "), schemeGem)
+	if err != nil {
+		return rangeWithScheme(Empty(), schemeGem)
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package vers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestParseGemRequirementString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input []string
+		want  string
+	}{
+		{nil, ">= 0"},
+		{[]string{}, ">= 0"},
+		{[]string{"1.0"}, "= 1.0"},
+		{[]string{"~> 7.0", ">= 7.0.4"}, "~> 7.0, >= 7.0.4"},
+		{[]string{">= 7.0.4", "~> 7.0"}, ">= 7.0.4, ~> 7.0"},
+		{[]string{"~> 7.0", "~> 7.0"}, "~> 7.0"},
+		{[]string{" >  0.a"}, "> 0.a"},
+		{[]string{">=1.2", "!= 1.5"}, ">= 1.2, != 1.5"},
+		{[]string{"= 1.0-beta"}, "= 1.0.pre.beta"},
+		{[]string{"1.02.3"}, "= 1.02.3"},
+	}
+	for _, tt := range tests {
+		req, err := ParseGemRequirement(tt.input)
+		if err != nil {
+			t.Errorf("ParseGemRequirement(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := req.String(); got != tt.want {
+			t.Errorf("ParseGemRequirement(%q).String() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseGemRequirementErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := [][]string{
+		{""},
+		{"~> 7.0, >= 7.0.4"},
+		{"=> 1.0"},
+		{"~> "},
+		{"1.0 beta"},
+		{">= 1.0", "latest"},
+	}
+	for _, input := range tests {
+		if _, err := ParseGemRequirement(input); err == nil {
+			t.Errorf("ParseGemRequirement(%q) expected error", input)
+		}
+	}
+}
+
+func TestGemRequirementSatisfiedByFixtures(t *testing.T) {
+	t.Parallel()
+
+	description := regexp.MustCompile(`^RubyGems range "(.*)" (?:contains|excludes) "(.*)"\.$`)
+	files := []string{"gem_range_reference_test.json", "gem_range_generated_test.json"}
+	for _, file := range files {
+		tf := loadTestFile(t, filepath.Join("testdata", "local", "tests", file))
+		for _, tc := range tf.Tests {
+			match := description.FindStringSubmatch(tc.Description)
+			if match == nil {
+				t.Fatalf("%s: unexpected description %q", file, tc.Description)
+			}
+			var want bool
+			if err := json.Unmarshal(tc.ExpectedOutput, &want); err != nil {
+				t.Fatalf("%s: parse expected output of %q: %v", file, tc.Description, err)
+			}
+			req, err := ParseGemRequirement([]string{match[1]})
+			if err != nil {
+				t.Errorf("%s: ParseGemRequirement(%q) error: %v", file, match[1], err)
+				continue
+			}
+			if got := req.SatisfiedBy(match[2]); got != want {
+				t.Errorf("%s: %q SatisfiedBy(%q) = %v, want %v", file, match[1], match[2], got, want)
+			}
+		}
+	}
+}
+
+func TestGemRequirementMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		requirements []string
+		version      string
+		satisfied    bool
+		matches      bool
+	}{
+		{[]string{"~> 7.0", ">= 7.0.4"}, "7.1.2", true, true},
+		{[]string{"~> 7.0", ">= 7.0.4"}, "7.0.3", false, false},
+		{[]string{"~> 7.0", ">= 7.0.4"}, "8.0.0", false, false},
+		{[]string{"~> 7.0", ">= 7.0.4"}, "7.2.0.rc1", true, false},
+		{[]string{"~> 7.0", "!= 7.0.5"}, "7.0.5", false, false},
+		{[]string{"~> 7.0", "!= 7.0.5"}, "7.0.6", true, true},
+		{[]string{"~> 7.2.a"}, "7.2.0.rc1", true, true},
+		{[]string{"~> 7.2.a"}, "7.3.0.beta", true, true},
+		{[]string{"~> 7.2.a"}, "8.0.0.beta", false, false},
+		{[]string{"~> 1.0", ">= 1.1.0-beta"}, "1.1.0.rc1", true, true},
+		{[]string{"= 1.0-beta"}, "1.0.pre.beta", true, true},
+		{[]string{"= 1.0-beta"}, "1.0-beta", true, true},
+		{nil, "1.0", true, true},
+		{nil, "1.0.a", true, true},
+		{[]string{">= 0"}, "1.0.a", true, true},
+		{[]string{">= 0.1"}, "1.0.a", true, false},
+		{nil, "", true, true},
+		{[]string{"~> 1.0"}, "2.0.a", false, false},
+		{[]string{"~> 1.0"}, "not a version", false, false},
+	}
+	for _, tt := range tests {
+		req, err := ParseGemRequirement(tt.requirements)
+		if err != nil {
+			t.Errorf("ParseGemRequirement(%q) error: %v", tt.requirements, err)
+			continue
+		}
+		if got := req.SatisfiedBy(tt.version); got != tt.satisfied {
+			t.Errorf("%q SatisfiedBy(%q) = %v, want %v", tt.requirements, tt.version, got, tt.satisfied)
+		}
+		if got := req.Matches(tt.version); got != tt.matches {
+			t.Errorf("%q Matches(%q) = %v, want %v", tt.requirements, tt.version, got, tt.matches)
+		}
+	}
+}
+
+func TestGemRequirementRange(t *testing.T) {
+	t.Parallel()
+
+	req, err := ParseGemRequirement([]string{"~> 7.0", ">= 7.0.4", "!= 7.1.0"})
+	if err != nil {
+		t.Fatalf("ParseGemRequirement error: %v", err)
+	}
+	r := req.Range()
+	if r.Scheme != schemeGem {
+		t.Errorf("Range().Scheme = %q, want %q", r.Scheme, schemeGem)
+	}
+	for version, want := range map[string]bool{"7.0.3": false, "7.0.4": true, "7.1.0": false, "7.9": true, "8.0": false} {
+		if got := r.Contains(version); got != want {
+			t.Errorf("Range().Contains(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
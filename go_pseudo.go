@@ -0,0 +1,170 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// goPseudoTimeFormat is the UTC commit timestamp layout in pseudo-versions.
+const goPseudoTimeFormat = "20060102150405"
+
+// goIncompatibleSuffix is the only build suffix the go command allows on a
+// pseudo-version, marking a v2+ module without a go.mod.
+const goIncompatibleSuffix = "+incompatible"
+
+// goPseudoVersionRegex matches the three pseudo-version forms:
+// vX.0.0-yyyymmddhhmmss-rev, vX.Y.Z-pre.0.yyyymmddhhmmss-rev and
+// vX.Y.Z-0.yyyymmddhhmmss-rev.
+var goPseudoVersionRegex = regexp.MustCompile(`^v[0-9]+\.(?:0\.0-|\d+\.\d+-(?:[^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+// GoPseudoVersion is a decoded Go pseudo-version, which names an untagged
+// commit relative to the latest tag before it.
+type GoPseudoVersion struct {
+	// Major is the major version prefix, such as v0 or v2.
+	Major string
+	// Base is the tag the pseudo-version follows, such as v1.2.3 or
+	// v1.2.3-pre, or empty when there was no earlier tag.
+	Base string
+	// Time is the UTC commit time, to the second.
+	Time time.Time
+	// Revision is the commit hash prefix, usually 12 hex digits.
+	Revision string
+	// Build is the build suffix, +incompatible or empty.
+	Build string
+}
+
+// isGoPseudoVersion reports whether version is a Go pseudo-version. Pseudo-
+// versions are prereleases of the release after their base tag, so they
+// already sort after the tag and before the next release.
+func isGoPseudoVersion(version string) bool {
+	if !goPseudoVersionRegex.MatchString(version) {
+		return false
+	}
+	_, ok := parseGoVersion(version)
+	return ok
+}
+
+// validGoPseudoTimestamp reports whether a version that has the shape of a
+// pseudo-version carries a real timestamp. Other versions are always valid.
+func validGoPseudoTimestamp(version string) bool {
+	if !goPseudoVersionRegex.MatchString(version) {
+		return true
+	}
+	_, _, timestamp, _, _ := splitGoPseudoVersion(version)
+	_, err := time.Parse(goPseudoTimeFormat, timestamp)
+	return err == nil
+}
+
+// splitGoPseudoVersion splits a pseudo-version into the version before its
+// timestamp, the timestamp, the revision and the build suffix. For
+// v1.2.4-0.20230101120000-abcdef123456 the prefix is v1.2.4-0, and for
+// v0.0.0-20230101120000-abcdef123456 it is v0.0.0.
+func splitGoPseudoVersion(version string) (prefix, sep, timestamp, revision, build string) {
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version, build = version[:i], version[i:]
+	}
+	i := strings.LastIndexByte(version, '-')
+	version, revision = version[:i], version[i+1:]
+	i = strings.LastIndexAny(version, "-.")
+	return version[:i], version[i : i+1], version[i+1:], revision, build
+}
+
+// ParseGoPseudoVersion decodes a Go pseudo-version into the tag it follows,
+// its commit time and its revision. The three forms are:
+//
+//	v0.0.0-20230101120000-abcdef123456        no earlier tag
+//	v1.2.4-0.20230101120000-abcdef123456      after release v1.2.3
+//	v1.2.3-pre.0.20230101120000-abcdef123456  after prerelease v1.2.3-pre
+func ParseGoPseudoVersion(version string) (*GoPseudoVersion, error) {
+	if !isGoPseudoVersion(version) {
+		return nil, fmt.Errorf("invalid go pseudo-version: %s", version)
+	}
+	prefix, sep, timestamp, revision, build := splitGoPseudoVersion(version)
+	if build != "" && build != goIncompatibleSuffix {
+		return nil, fmt.Errorf("invalid go pseudo-version: %s: build suffix other than %s", version, goIncompatibleSuffix)
+	}
+	t, _ := time.Parse(goPseudoTimeFormat, timestamp)
+	parsed, _ := parseGoVersion(prefix)
+	p := &GoPseudoVersion{Major: "v" + parsed.core[0], Time: t, Revision: revision, Build: build}
+
+	switch {
+	case sep == "-":
+		// vX.0.0-yyyymmddhhmmss-rev has no base, and a +incompatible
+		// suffix would have nothing to qualify.
+		if build != "" {
+			return nil, fmt.Errorf("invalid go pseudo-version: %s: build suffix without a base version", version)
+		}
+	case parsed.pre == "0":
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-rev follows release vX.Y.Z.
+		if parsed.core[2] == "0" {
+			return nil, fmt.Errorf("invalid go pseudo-version: %s: patch version cannot be decremented", version)
+		}
+		p.Base = "v" + parsed.core[0] + "." + parsed.core[1] + "." + decNumStr(parsed.core[2])
+	default:
+		// vX.Y.Z-pre.0.yyyymmddhhmmss-rev follows prerelease vX.Y.Z-pre.
+		p.Base = strings.TrimSuffix(prefix, ".0")
+	}
+	return p, nil
+}
+
+// String returns the pseudo-version in canonical form.
+func (p *GoPseudoVersion) String() string {
+	v, err := NewGoPseudoVersion(p.Major, p.Base+p.Build, p.Time, p.Revision)
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+// NewGoPseudoVersion builds the pseudo-version for a commit made at t with
+// the given revision, usually the first 12 hex digits of the commit hash.
+// base is the latest tag before the commit, or empty if there is none, in
+// which case major (such as v2, defaulting to v0) sets the major version.
+// A +incompatible suffix on base is carried over; other build suffixes
+// are rejected.
+func NewGoPseudoVersion(major, base string, t time.Time, revision string) (string, error) {
+	if revision == "" || !containsOnly(revision, isASCIIAlnum) {
+		return "", fmt.Errorf("invalid go pseudo-version revision: %s", revision)
+	}
+	segment := t.UTC().Format(goPseudoTimeFormat) + "-" + revision
+
+	if base == "" {
+		if major == "" {
+			major = "v0"
+		}
+		if len(major) < 2 || major[0] != 'v' || !isDigits(major[1:]) || len(major) > 2 && major[1] == '0' {
+			return "", fmt.Errorf("invalid go major version: %s", major)
+		}
+		return major + ".0.0-" + segment, nil
+	}
+
+	parsed, ok := parseGoVersion(base)
+	if !ok || isGoVersionPrefix(base) {
+		return "", fmt.Errorf("invalid go version: %s", base)
+	}
+	var build string
+	if i := strings.IndexByte(base, '+'); i >= 0 {
+		base, build = base[:i], base[i:]
+	}
+	if build != "" && build != goIncompatibleSuffix {
+		return "", fmt.Errorf("invalid go version: %s%s: build suffix other than %s", base, build, goIncompatibleSuffix)
+	}
+	if parsed.pre != "" {
+		return base + ".0." + segment + build, nil
+	}
+	patch := incNumStr(parsed.core[2])
+	return "v" + parsed.core[0] + "." + parsed.core[1] + "." + patch + "-0." + segment + build, nil
+}
+
+// decNumStr returns the decimal string s-1 for a positive integer string.
+func decNumStr(s string) string {
+	digits := []byte(s)
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '0'; i-- {
+		digits[i] = '9'
+	}
+	digits[i]--
+	return trimLeadingZeros(string(digits))
+}
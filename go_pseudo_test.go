@@ -0,0 +1,127 @@
+package vers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseGoPseudoVersion(t *testing.T) {
+	t.Parallel()
+
+	commit := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		major string
+		base  string
+		build string
+	}{
+		{"v0.0.0-20230101120000-abcdef123456", "v0", "", ""},
+		{"v2.0.0-20230101120000-abcdef123456", "v2", "", ""},
+		{"v1.2.4-0.20230101120000-abcdef123456", "v1", "v1.2.3", ""},
+		{"v1.2.10-0.20230101120000-abcdef123456", "v1", "v1.2.9", ""},
+		{"v1.2.3-pre.0.20230101120000-abcdef123456", "v1", "v1.2.3-pre", ""},
+		{"v1.2.3-rc.1.0.20230101120000-abcdef123456", "v1", "v1.2.3-rc.1", ""},
+		{"v2.0.1-0.20230101120000-abcdef123456+incompatible", "v2", "v2.0.0", "+incompatible"},
+	}
+	for _, tt := range tests {
+		p, err := ParseGoPseudoVersion(tt.input)
+		if err != nil {
+			t.Errorf("ParseGoPseudoVersion(%q) error: %v", tt.input, err)
+			continue
+		}
+		if p.Major != tt.major || p.Base != tt.base || p.Build != tt.build ||
+			!p.Time.Equal(commit) || p.Revision != "abcdef123456" {
+			t.Errorf("ParseGoPseudoVersion(%q) = %+v", tt.input, p)
+		}
+		if got := p.String(); got != tt.input {
+			t.Errorf("ParseGoPseudoVersion(%q).String() = %q", tt.input, got)
+		}
+	}
+}
+
+func TestParseGoPseudoVersionErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"v1.2.3",
+		"v1.2.4-alpha",
+		"1.2.4-0.20230101120000-abcdef123456",
+		"v1.2.0-0.20230101120000-abcdef123456",
+		"v1.2.4-0.20231301120000-abcdef123456",
+		"v0.0.0-20230101120000-abcdef123456+incompatible",
+		"v2.0.1-0.20230101120000-abcdef123456+build.5",
+		"v1.2.4-0.2023010112000-abcdef123456",
+	} {
+		if p, err := ParseGoPseudoVersion(input); err == nil {
+			t.Errorf("ParseGoPseudoVersion(%q) = %+v, expected error", input, p)
+		}
+	}
+}
+
+func TestNewGoPseudoVersion(t *testing.T) {
+	t.Parallel()
+
+	commit := time.Date(2023, 1, 1, 7, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	tests := []struct {
+		major string
+		base  string
+		want  string
+	}{
+		{"", "", "v0.0.0-20230101120000-abcdef123456"},
+		{"v2", "", "v2.0.0-20230101120000-abcdef123456"},
+		{"", "v1.2.3", "v1.2.4-0.20230101120000-abcdef123456"},
+		{"", "v1.2.9", "v1.2.10-0.20230101120000-abcdef123456"},
+		{"", "v1.2.3-pre", "v1.2.3-pre.0.20230101120000-abcdef123456"},
+		{"", "v2.0.0+incompatible", "v2.0.1-0.20230101120000-abcdef123456+incompatible"},
+	}
+	for _, tt := range tests {
+		got, err := NewGoPseudoVersion(tt.major, tt.base, commit, "abcdef123456")
+		if err != nil || got != tt.want {
+			t.Errorf("NewGoPseudoVersion(%q, %q) = %q, %v, want %q", tt.major, tt.base, got, err, tt.want)
+		}
+		if tt.base != "" && CompareWithScheme(got, tt.base, "go") <= 0 {
+			t.Errorf("CompareWithScheme(%q, %q, go) <= 0, want > 0", got, tt.base)
+		}
+		if !ValidWithScheme(got, "go") {
+			t.Errorf("ValidWithScheme(%q, go) = false", got)
+		}
+	}
+
+	for _, tt := range []struct{ major, base, rev string }{
+		{"", "v1.2", "abc"},
+		{"", "1.2.3", "abc"},
+		{"2", "", "abc"},
+		{"v02", "", "abc"},
+		{"", "v1.2.3", ""},
+		{"", "v1.2.3", "abc-def"},
+		{"", "v2.0.0+build", "abc"},
+	} {
+		if got, err := NewGoPseudoVersion(tt.major, tt.base, commit, tt.rev); err == nil {
+			t.Errorf("NewGoPseudoVersion(%q, %q, %q) = %q, expected error", tt.major, tt.base, tt.rev, got)
+		}
+	}
+}
+
+func TestValidWithSchemeGoPseudoVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"v1.2.4-0.20230101120000-abcdef123456", true},
+		{"v1.2.4-0.20231301120000-abcdef123456", false},
+		{"v0.0.0-20230230120000-abcdef123456", false},
+		{"v01.2.3", false},
+		{"v1.2.3-01", false},
+		{"1.2.3", true},
+	}
+	for _, tt := range tests {
+		if got := ValidWithScheme(tt.version, "go"); got != tt.want {
+			t.Errorf("ValidWithScheme(%q, go) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+	if CompareWithScheme("v1.2.4-0.20230101120000-abcdef123456", "v1.2.4-0.20230102120000-abcdef123456", "go") >= 0 {
+		t.Error("later pseudo-versions of the same base should sort higher")
+	}
+}
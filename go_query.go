@@ -0,0 +1,250 @@
+package vers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GoQueryKind classifies a go get version query.
+type GoQueryKind int
+
+const (
+	// GoQueryLatest selects the highest release, or the highest prerelease
+	// when there are no releases.
+	GoQueryLatest GoQueryKind = iota
+	// GoQueryUpgrade is like GoQueryLatest but never selects a version below
+	// the current one.
+	GoQueryUpgrade
+	// GoQueryPatch selects the latest release with the current major and
+	// minor version, never below the current one.
+	GoQueryPatch
+	// GoQueryVersion is a complete version such as v1.2.3, v2.0.0+incompatible
+	// or a pseudo-version.
+	GoQueryVersion
+	// GoQueryPrefix is a version prefix such as v1 or v1.2, selecting the
+	// latest version that starts with it.
+	GoQueryPrefix
+	// GoQueryComparison is a comparison such as >=v1.2.3 or <v1.5.
+	GoQueryComparison
+)
+
+// String returns the lowercase name of the kind.
+func (k GoQueryKind) String() string {
+	switch k {
+	case GoQueryLatest:
+		return "latest"
+	case GoQueryUpgrade:
+		return "upgrade"
+	case GoQueryPatch:
+		return "patch"
+	case GoQueryVersion:
+		return "version"
+	case GoQueryPrefix:
+		return "prefix"
+	case GoQueryComparison:
+		return "comparison"
+	}
+	return fmt.Sprintf("GoQueryKind(%d)", int(k))
+}
+
+// GoQuery is a parsed go get version query, the part after @ in
+// go get example.com/mod@v1.2.
+type GoQuery struct {
+	Kind GoQueryKind
+	// Operator is <, <=, > or >= for a comparison and empty otherwise.
+	Operator string
+	// Version is the version, prefix or comparison operand, with its v.
+	Version string
+}
+
+// ParseGoQuery parses a go get version query: latest, upgrade, patch, a
+// version such as v1.2.3, a prefix such as v1 or v1.2, or a comparison such
+// as >=v1.2.3 or <v1.5. Branch names and commit hashes need the repository to
+// resolve and are rejected.
+func ParseGoQuery(query string) (*GoQuery, error) {
+	switch query {
+	case "latest":
+		return &GoQuery{Kind: GoQueryLatest}, nil
+	case "upgrade":
+		return &GoQuery{Kind: GoQueryUpgrade}, nil
+	case "patch":
+		return &GoQuery{Kind: GoQueryPatch}, nil
+	}
+
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if !strings.HasPrefix(query, op) {
+			continue
+		}
+		version := query[len(op):]
+		if _, ok := parseGoVersion(version); !ok {
+			return nil, fmt.Errorf("invalid go query: %s", query)
+		}
+		// As in the go command, <=v1.2 and >v1.2 are refused because v1.2
+		// might mean v1.2.3.
+		if (op == "<=" || op == ">") && isGoVersionPrefix(version) {
+			return nil, fmt.Errorf("invalid go query: ambiguous version %s in %s", version, query)
+		}
+		return &GoQuery{Kind: GoQueryComparison, Operator: op, Version: version}, nil
+	}
+
+	if _, ok := parseGoVersion(query); !ok {
+		return nil, fmt.Errorf("invalid go query: %s", query)
+	}
+	if isGoVersionPrefix(query) {
+		return &GoQuery{Kind: GoQueryPrefix, Version: query}, nil
+	}
+	return &GoQuery{Kind: GoQueryVersion, Version: query}, nil
+}
+
+// isGoVersionPrefix reports whether a valid Go version omits its minor or
+// patch number, as in v1 or v1.2.
+func isGoVersionPrefix(version string) bool {
+	return !strings.ContainsAny(version, "-+") && strings.Count(version, ".") < 2
+}
+
+// String returns the query as go get accepts it.
+func (q *GoQuery) String() string {
+	switch q.Kind {
+	case GoQueryLatest, GoQueryUpgrade, GoQueryPatch:
+		return q.Kind.String()
+	}
+	return q.Operator + q.Version
+}
+
+// Range returns the versions the query can select from, using the go scheme.
+// latest, upgrade and patch depend on the available and current versions, so
+// their range is unbounded. A prefix such as v1.2 covers v1.2.0 up to, but
+// not including, the prereleases of v1.3.0.
+func (q *GoQuery) Range() *Range {
+	var r *Range
+	switch q.Kind {
+	case GoQueryVersion:
+		r = NewRange([]Interval{ExactInterval(q.Version)})
+	case GoQueryPrefix:
+		r = NewRange([]Interval{NewInterval(q.Version, nextGoPrefix(q.Version)+"-0", true, false)})
+	case GoQueryComparison:
+		c := &Constraint{Operator: q.Operator, Version: q.Version}
+		interval, _ := c.ToInterval()
+		r = NewRange([]Interval{interval})
+	default:
+		r = Unbounded()
+	}
+	r.Scheme = schemeGo
+	return r
+}
+
+// nextGoPrefix returns the release after a prefix: v2.0.0 for v1, v1.3.0 for v1.2.
+func nextGoPrefix(prefix string) string {
+	parts := strings.Split(strings.TrimPrefix(prefix, "v"), ".")
+	parts[len(parts)-1] = incNumStr(parts[len(parts)-1])
+	for len(parts) < 3 { //nolint:mnd
+		parts = append(parts, "0")
+	}
+	return "v" + strings.Join(parts, ".")
+}
+
+// Resolve selects the version the go command would pick for the query from
+// the module's tagged versions. current is the version already required, or
+// empty or "none" if there is none; upgrade and patch never select a lower
+// version than it. As in the go command, releases are preferred over
+// prereleases, comparisons with > and >= pick the lowest match and the rest
+// pick the highest. Pseudo-versions in versions are ignored unless the query
+// names one exactly. Retractions and +incompatible filtering require the
+// module's go.mod files and are not applied.
+func (q *GoQuery) Resolve(versions []string, current string) (string, error) {
+	if current == "none" {
+		current = ""
+	}
+	if current != "" {
+		if _, ok := parseGoVersion(current); !ok {
+			return "", fmt.Errorf("invalid go version: %s", current)
+		}
+	}
+
+	if q.Kind == GoQueryVersion {
+		return q.resolveVersion(versions)
+	}
+
+	var match func(string) bool
+	preferLower := false
+	switch q.Kind {
+	case GoQueryPatch:
+		if current != "" {
+			prefix := goMajorMinor(current) + "."
+			match = func(v string) bool { return strings.HasPrefix(v, prefix) }
+		}
+	case GoQueryPrefix:
+		prefix := q.Version + "."
+		match = func(v string) bool {
+			return strings.HasPrefix(v, prefix) && compareGo(v, q.Version) >= 0
+		}
+	case GoQueryComparison:
+		c := &Constraint{Operator: q.Operator, Version: q.Version}
+		match = func(v string) bool { return c.satisfiesCmp(v, compareGo) }
+		preferLower = q.Operator == ">" || q.Operator == ">="
+	}
+
+	result, ok := pickGoVersion(versions, match, preferLower)
+	if q.Kind == GoQueryUpgrade || q.Kind == GoQueryPatch {
+		if current != "" && (!ok || compareGo(current, result) > 0) {
+			return current, nil
+		}
+	}
+	if !ok {
+		return "", fmt.Errorf("no matching versions for go query: %s", q)
+	}
+	return result, nil
+}
+
+// resolveVersion finds an exact version among versions. A pseudo-version
+// names a commit rather than a tag, so it is returned as is.
+func (q *GoQuery) resolveVersion(versions []string) (string, error) {
+	if isGoPseudoVersion(q.Version) {
+		return q.Version, nil
+	}
+	if slices.Contains(versions, q.Version) {
+		return q.Version, nil
+	}
+	// v2.0.0 also finds v2.0.0+incompatible, as build metadata is ignored.
+	for _, v := range versions {
+		if _, ok := parseGoVersion(v); ok && compareGo(v, q.Version) == 0 {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown go version: %s", q.Version)
+}
+
+// pickGoVersion returns the highest, or with preferLower the lowest, tagged
+// release accepted by match, falling back to prereleases when no release
+// matches. A nil match accepts every version.
+func pickGoVersion(versions []string, match func(string) bool, preferLower bool) (string, bool) {
+	var releases, prereleases []string
+	for _, v := range versions {
+		parsed, ok := parseGoVersion(v)
+		if !ok || isGoPseudoVersion(v) || match != nil && !match(v) {
+			continue
+		}
+		if parsed.pre == "" {
+			releases = append(releases, v)
+		} else {
+			prereleases = append(prereleases, v)
+		}
+	}
+	for _, candidates := range [][]string{releases, prereleases} {
+		if len(candidates) == 0 {
+			continue
+		}
+		if preferLower {
+			return slices.MinFunc(candidates, compareGo), true
+		}
+		return slices.MaxFunc(candidates, compareGo), true
+	}
+	return "", false
+}
+
+// goMajorMinor returns the vX.Y prefix of a valid Go version.
+func goMajorMinor(version string) string {
+	parsed, _ := parseGoVersion(version)
+	return "v" + parsed.core[0] + "." + parsed.core[1]
+}
@@ -0,0 +1,199 @@
+package vers
+
+import "testing"
+
+func TestParseGoQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		kind     GoQueryKind
+		operator string
+		version  string
+	}{
+		{"latest", GoQueryLatest, "", ""},
+		{"upgrade", GoQueryUpgrade, "", ""},
+		{"patch", GoQueryPatch, "", ""},
+		{"v1.2.3", GoQueryVersion, "", "v1.2.3"},
+		{"v2.0.0+incompatible", GoQueryVersion, "", "v2.0.0+incompatible"},
+		{"v0.0.0-20230101120000-abcdef123456", GoQueryVersion, "", "v0.0.0-20230101120000-abcdef123456"},
+		{"v1", GoQueryPrefix, "", "v1"},
+		{"v1.2", GoQueryPrefix, "", "v1.2"},
+		{">=v1.2.3", GoQueryComparison, ">=", "v1.2.3"},
+		{"<v1.5", GoQueryComparison, "<", "v1.5"},
+		{"<=v1.5.0", GoQueryComparison, "<=", "v1.5.0"},
+		{">v1.5.0-rc.1", GoQueryComparison, ">", "v1.5.0-rc.1"},
+	}
+	for _, tt := range tests {
+		q, err := ParseGoQuery(tt.input)
+		if err != nil {
+			t.Errorf("ParseGoQuery(%q) error: %v", tt.input, err)
+			continue
+		}
+		if q.Kind != tt.kind || q.Operator != tt.operator || q.Version != tt.version {
+			t.Errorf("ParseGoQuery(%q) = %+v", tt.input, q)
+		}
+		if got := q.String(); got != tt.input {
+			t.Errorf("ParseGoQuery(%q).String() = %q", tt.input, got)
+		}
+	}
+}
+
+func TestParseGoQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "1.2.3", "v01.2.3", "main", "abcdef1", ">=1.2.3", "<=v1.2", ">v1", "v1.2-pre", "Latest"} {
+		if q, err := ParseGoQuery(input); err == nil {
+			t.Errorf("ParseGoQuery(%q) = %+v, expected error", input, q)
+		}
+	}
+}
+
+func TestGoQueryResolve(t *testing.T) {
+	t.Parallel()
+
+	versions := []string{
+		"v1.0.0", "v1.2.0", "v1.2.3", "v1.2.4-rc.1", "v1.3.0-beta", "v1.3.0-0.20230101120000-abcdef123456",
+		"v1.4.0", "v1.5.0", "v1.5.1", "v2.0.0+incompatible",
+	}
+
+	tests := []struct {
+		query   string
+		current string
+		want    string
+	}{
+		{"latest", "", "v2.0.0+incompatible"},
+		{"v1", "", "v1.5.1"},
+		{"v1.2", "", "v1.2.3"},
+		{"v1.3", "", ""}, // v1.3 never selects prereleases of v1.3.0
+		{"v1.2.3", "", "v1.2.3"},
+		{"v2.0.0", "", "v2.0.0+incompatible"},
+		{"v0.0.0-20230101120000-abcdef123456", "", "v0.0.0-20230101120000-abcdef123456"},
+		{">=v1.2.1", "", "v1.2.3"},
+		{">v1.2.3", "", "v1.4.0"},
+		{"<v1.5", "", "v1.4.0"},
+		{"<=v1.2.3", "", "v1.2.3"},
+		{"<v1.0.0", "", ""},
+		{"upgrade", "v1.2.0", "v2.0.0+incompatible"},
+		{"upgrade", "v3.0.0-0.20240101120000-abcdef123456", "v3.0.0-0.20240101120000-abcdef123456"},
+		{"patch", "v1.2.0", "v1.2.3"},
+		{"patch", "v1.5.2-rc.1", "v1.5.2-rc.1"},
+		{"patch", "v1.7.0", "v1.7.0"},
+		{"patch", "none", "v2.0.0+incompatible"},
+	}
+	for _, tt := range tests {
+		q, err := ParseGoQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseGoQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := q.Resolve(versions, tt.current)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Resolve(%q, %q) = %q, expected error", tt.query, tt.current, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %q, %v, want %q", tt.query, tt.current, got, err, tt.want)
+		}
+	}
+
+	q, _ := ParseGoQuery("latest")
+	if got, err := q.Resolve([]string{"v0.1.0-alpha", "v0.1.0-beta"}, ""); err != nil || got != "v0.1.0-beta" {
+		t.Errorf("latest with only prereleases = %q, %v, want v0.1.0-beta", got, err)
+	}
+	if _, err := q.Resolve(nil, "1.2.3"); err == nil {
+		t.Error("Resolve with an invalid current version expected error")
+	}
+	q, _ = ParseGoQuery("v1.9.9")
+	if _, err := q.Resolve(versions, ""); err == nil {
+		t.Error("Resolve of an unknown version expected error")
+	}
+}
+
+func TestGoQueryRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query    string
+		contains []string
+		excludes []string
+	}{
+		{"v1.2", []string{"v1.2.0", "v1.2.9", "v1.2.10-rc.1"}, []string{"v1.2.0-rc.1", "v1.3.0-0.20230101120000-abcdef123456", "v1.3.0"}},
+		{"v1", []string{"v1.0.0", "v1.99.0"}, []string{"v0.9.0", "v2.0.0-alpha"}},
+		{"<v1.5", []string{"v1.4.9", "v1.5.0-rc.1"}, []string{"v1.5.0"}},
+		{">=v1.2.3", []string{"v1.2.3", "v1.2.4-0.20230101120000-abcdef123456"}, []string{"v1.2.3-rc.1"}},
+		{"v2.0.0+incompatible", []string{"v2.0.0+incompatible"}, []string{"v2.0.1"}},
+		{"latest", []string{"v0.0.0-20230101120000-abcdef123456", "v9.0.0"}, nil},
+	}
+	for _, tt := range tests {
+		q, err := ParseGoQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseGoQuery(%q) error: %v", tt.query, err)
+		}
+		r := q.Range()
+		for _, v := range tt.contains {
+			if !r.Contains(v) {
+				t.Errorf("%q.Range().Contains(%q) = false, want true", tt.query, v)
+			}
+		}
+		for _, v := range tt.excludes {
+			if r.Contains(v) {
+				t.Errorf("%q.Range().Contains(%q) = true, want false", tt.query, v)
+			}
+		}
+	}
+}
+
+func TestGoPseudoVersionOrdering(t *testing.T) {
+	t.Parallel()
+
+	ordered := []string{
+		"v0.0.0-20230101120000-abcdef123456",
+		"v0.0.0-20230102120000-abcdef123456",
+		"v1.2.3",
+		"v1.2.4-0.20230101120000-abcdef123456",
+		"v1.2.4-alpha",
+		"v1.2.4-alpha.0.20230101120000-abcdef123456",
+		"v1.2.4-beta",
+		"v1.2.4",
+	}
+	for i := 1; i < len(ordered); i++ {
+		if compareGo(ordered[i-1], ordered[i]) >= 0 {
+			t.Errorf("compareGo(%q, %q) >= 0, want < 0", ordered[i-1], ordered[i])
+		}
+	}
+	for _, v := range []string{ordered[0], ordered[3], ordered[5], "v1.2.4-0.20230101120000-abcdef123456+incompatible"} {
+		if !isGoPseudoVersion(v) {
+			t.Errorf("isGoPseudoVersion(%q) = false, want true", v)
+		}
+	}
+	for _, v := range []string{"v1.2.3", "v1.2.4-alpha", "v1.2.4-0.2023-abc", "v1.2.3-20230101120000-abcdef123456"} {
+		if isGoPseudoVersion(v) {
+			t.Errorf("isGoPseudoVersion(%q) = true, want false", v)
+		}
+	}
+}
+
+func TestParseNativeGoKeepsExclusions(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseNative(">=v1.2.3, <v2.0.0, !=v1.4.0", "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Contains("v1.4.0") || !r.Contains("v1.4.1") {
+		t.Errorf("range %v should exclude only v1.4.0", r)
+	}
+	if got := ToVersString(r, "golang"); got != "vers:golang/>=v1.2.3|!=v1.4.0|<v2.0.0" {
+		t.Errorf("ToVersString = %q", got)
+	}
+
+	r, err = ParseNative("!=v1.4.0, !=v1.5.0", "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Contains("v1.5.0") || !r.Contains("v1.0.0") {
+		t.Errorf("range %v should exclude v1.4.0 and v1.5.0", r)
+	}
+}
@@ -0,0 +1,266 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Gradle dynamic versions, ranges and rich versions.
+// https://docs.gradle.org/current/userguide/dynamic_versions.html
+// https://docs.gradle.org/current/userguide/rich_versions.html
+//
+// A version splits into parts at ., -, _ and +, and wherever digits meet
+// other characters, so 1.0rc1 has the parts 1, 0, rc and 1. Numbers sort
+// numerically and after strings; dev sorts before every other string and
+// rc < snapshot < final < ga < release < sp sort after them. An extra
+// numeric part makes a version higher and an extra string part makes it
+// lower, so 1.1.rc < 1.1 < 1.1.0.
+
+// gradleSpecialParts ranks the qualifiers Gradle's version comparator
+// treats specially. Other strings rank 0.
+var gradleSpecialParts = map[string]int{
+	"dev":      -1,
+	"rc":       1,
+	"snapshot": 2,
+	"final":    3,
+	"ga":       4,
+	"release":  5,
+	"sp":       6,
+}
+
+var gradleTrailingNumberRegex = regexp.MustCompile(`[0-9]+$`)
+
+// GradleRichVersion is a rich version declaration, as written in a Gradle
+// version block or version catalog.
+type GradleRichVersion struct {
+	// Strictly rejects every version it does not select.
+	Strictly string
+	// Require sets the lowest acceptable version. Conflict resolution may
+	// still pick a higher one.
+	Require string
+	// Prefer only guides resolution and does not narrow the range.
+	Prefer string
+	// Reject lists versions or ranges that must never be selected.
+	Reject []string
+}
+
+// ParseGradleRichVersion converts a Gradle rich version into a range.
+// Rejected versions become exclusions and rejected ranges are removed
+// from the result.
+func ParseGradleRichVersion(rich GradleRichVersion) (*Range, error) {
+	result := rangeWithScheme(Unbounded(), schemeGradle)
+	if rich.Strictly != "" {
+		r, err := parseGradleSelector(rich.Strictly, true)
+		if err != nil {
+			return nil, err
+		}
+		result = r
+	}
+	if rich.Require != "" {
+		r, err := parseGradleSelector(rich.Require, false)
+		if err != nil {
+			return nil, err
+		}
+		result = result.Intersect(gradleRequiredRange(r))
+	}
+	if rich.Prefer != "" && !validGradleVersion(rich.Prefer) {
+		return nil, fmt.Errorf("invalid gradle version: %s", rich.Prefer)
+	}
+	for _, reject := range rich.Reject {
+		r, err := parseGradleSelector(reject, true)
+		if err != nil {
+			return nil, err
+		}
+		if version, ok := r.ExactVersion(); ok {
+			result = result.Exclude(version)
+			continue
+		}
+		for _, interval := range r.Intervals {
+			result = result.Intersect(rangeWithScheme(NewRange(gradleComplement(interval)), schemeGradle))
+		}
+	}
+	result.RawConstraints = nil
+	return result, nil
+}
+
+// parseGradleRange parses a Gradle version declaration. A plain version is
+// a require: it sets the lowest acceptable version, as in Maven. A version
+// followed by !! is strict, and the text after !! is a preferred version.
+func (p *Parser) parseGradleRange(constraint string) (*Range, error) {
+	constraint = strings.TrimSpace(constraint)
+	if strict, _, ok := strings.Cut(constraint, "!!"); ok {
+		return parseGradleSelector(strict, true)
+	}
+	return parseGradleSelector(constraint, false)
+}
+
+// parseGradleSelector parses a single version selector. When strict is set,
+// a plain version selects only itself.
+func parseGradleSelector(selector string, strict bool) (*Range, error) {
+	selector = strings.TrimSpace(selector)
+	switch {
+	case selector == "":
+		return nil, fmt.Errorf("empty gradle constraint")
+	case selector == "+", selector == "latest.integration", selector == "latest.milestone", selector == "latest.release":
+		// Status is repository metadata, so every latest.* selector admits
+		// every version.
+		return rangeWithScheme(Unbounded(), schemeGradle), nil
+	case strings.HasSuffix(selector, "+"):
+		return parseGradlePrefix(selector)
+	case strings.ContainsAny(selector[:1], "[(]") && strings.ContainsAny(selector[len(selector)-1:], "[)]"):
+		return parseGradleBrackets(selector)
+	case !validGradleVersion(selector):
+		return nil, fmt.Errorf("invalid gradle version: %s", selector)
+	case strict:
+		return rangeWithScheme(Exact(selector), schemeGradle), nil
+	default:
+		return rangeWithScheme(GreaterThan(selector, true), schemeGradle), nil
+	}
+}
+
+// parseGradlePrefix expands a prefix selector. 1.2.+ matches versions that
+// start with "1.2.", so it excludes 1.2 itself and stops before 1.3-dev,
+// the lowest 1.3 qualifier, keeping out 1.3-rc1 and 1.3-SNAPSHOT too. Only
+// versions such as 1.3-dev-x, which Gradle sorts below 1.3-dev, still fall
+// inside.
+func parseGradlePrefix(selector string) (*Range, error) {
+	base, ok := strings.CutSuffix(selector, ".+")
+	if !ok || !validGradleVersion(base) {
+		return nil, fmt.Errorf("unsupported gradle prefix version: %s", selector)
+	}
+	last := gradleTrailingNumberRegex.FindStringIndex(base)
+	if last == nil {
+		return nil, fmt.Errorf("unsupported gradle prefix version: %s", selector)
+	}
+	upper := base[:last[0]] + incNumStr(base[last[0]:]) + "-dev"
+	return rangeWithScheme(NewRange([]Interval{NewInterval(base, upper, false, false)}), schemeGradle), nil
+}
+
+// parseGradleBrackets parses a range, where ] on the left and [ on the
+// right are exclusive like ( and ).
+func parseGradleBrackets(selector string) (*Range, error) {
+	opening, closing := selector[0], selector[len(selector)-1]
+	lower, upper, ok := strings.Cut(selector[1:len(selector)-1], ",")
+	if !ok || strings.Contains(upper, ",") {
+		return nil, fmt.Errorf("invalid gradle range: %s", selector)
+	}
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	if (lower == "" && upper == "") ||
+		(lower != "" && !validGradleVersion(lower)) || (upper != "" && !validGradleVersion(upper)) ||
+		(lower == "" && opening == '[') || (upper == "" && closing == ']') {
+		return nil, fmt.Errorf("invalid gradle range: %s", selector)
+	}
+	interval := NewInterval(lower, upper, opening == '[', closing == ']')
+	return rangeWithScheme(NewRange([]Interval{interval}), schemeGradle), nil
+}
+
+// gradleRequiredRange keeps only the lower bound of a selector, since a
+// required version may be raised past its upper bound by conflict resolution.
+func gradleRequiredRange(r *Range) *Range {
+	if len(r.Intervals) == 0 || r.Intervals[0].Min == "" {
+		return rangeWithScheme(Unbounded(), schemeGradle)
+	}
+	lower := r.Intervals[0]
+	return rangeWithScheme(GreaterThan(lower.Min, lower.MinInclusive), schemeGradle)
+}
+
+// gradleComplement returns the intervals outside a rejected interval.
+func gradleComplement(interval Interval) []Interval {
+	var intervals []Interval
+	if interval.Min != "" {
+		intervals = append(intervals, LessThanInterval(interval.Min, !interval.MinInclusive))
+	}
+	if interval.Max != "" {
+		intervals = append(intervals, GreaterThanInterval(interval.Max, !interval.MaxInclusive))
+	}
+	if len(intervals) == 0 {
+		return []Interval{EmptyInterval()}
+	}
+	return intervals
+}
+
+func validGradleVersion(version string) bool {
+	return version != "" && !strings.ContainsAny(version, " \t\r\n[](),!")
+}
+
+// splitGradleVersion splits a version into parts the way Gradle's
+// VersionParser does.
+func splitGradleVersion(version string) []string {
+	var parts []string
+	start := 0
+	digit := false
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.' || c == '_' || c == '-' || c == '+':
+			parts = append(parts, version[start:i])
+			start = i + 1
+			digit = false
+		case isASCIIDigit(c):
+			if !digit && i > start {
+				parts = append(parts, version[start:i])
+				start = i
+			}
+			digit = true
+		default:
+			if digit {
+				parts = append(parts, version[start:i])
+				start = i
+			}
+			digit = false
+		}
+	}
+	if start < len(version) {
+		parts = append(parts, version[start:])
+	}
+	return parts
+}
+
+// compareGradle compares two versions using Gradle's version ordering.
+func compareGradle(a, b string) int {
+	if a == b {
+		return 0
+	}
+	partsA, partsB := splitGradleVersion(a), splitGradleVersion(b)
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if c := compareGradlePart(partsA[i], partsB[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(partsA) > len(partsB):
+		if isDigits(partsA[len(partsB)]) {
+			return 1
+		}
+		return -1
+	case len(partsA) < len(partsB):
+		if isDigits(partsB[len(partsA)]) {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareGradlePart(a, b string) int {
+	if a == b {
+		return 0
+	}
+	aNumeric, bNumeric := isDigits(a), isDigits(b)
+	switch {
+	case aNumeric && bNumeric:
+		return cmpNumStr(a, b)
+	case aNumeric:
+		return 1
+	case bNumeric:
+		return -1
+	}
+	specialA, okA := gradleSpecialParts[strings.ToLower(a)]
+	specialB, okB := gradleSpecialParts[strings.ToLower(b)]
+	if okA || okB {
+		return cmpInt(specialA, specialB)
+	}
+	return cmpString(a, b)
+}
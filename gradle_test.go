@@ -0,0 +1,146 @@
+package vers
+
+import "testing"
+
+func TestParseGradleRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.+", "1.0", true},
+		{"1.+", "1.9.9", true},
+		{"1.+", "1", false},
+		{"1.+", "2.0", false},
+		{"1.+", "10.0", false},
+		{"1.2.+", "1.2.0", true},
+		{"1.2.+", "1.2.15", true},
+		{"1.2.+", "1.2", false},
+		{"1.2.+", "1.3.0", false},
+		{"1.2.+", "1.2.99-SNAPSHOT", true},
+		{"1.2.+", "1.3-rc1", false},
+		{"1.2.+", "1.3-SNAPSHOT", false},
+		{"1.2.+", "1.3-alpha", false},
+		{"1.2.+", "1.3-dev", false},
+		{"1.+", "2.0-rc1", false},
+		{"1.+", "2-SNAPSHOT", false},
+		{"+", "0.0.1", true},
+		{"latest.release", "3.0", true},
+		{"latest.integration", "3.0-SNAPSHOT", true},
+		{"[1.0,2.0[", "1.0", true},
+		{"[1.0,2.0[", "1.9", true},
+		{"[1.0,2.0[", "2.0", false},
+		{"]1.0,2.0]", "1.0", false},
+		{"]1.0,2.0]", "2.0", true},
+		{"[1.0,2.0)", "2.0", false},
+		{"(1.0,2.0]", "1.0", false},
+		{"[1.0,)", "99", true},
+		{"],2.0]", "0.1", true},
+		{"(,2.0[", "2.0", false},
+		{"[1.0, 2.0[", "1.5", true},
+		{"1.5", "1.5", true},
+		{"1.5", "1.6", true},
+		{"1.5", "1.4", false},
+		{"1.5!!", "1.6", false},
+		{"1.5!!", "1.5", true},
+		{"[1.7,1.8[!!1.7.25", "1.7.30", true},
+		{"[1.7,1.8[!!1.7.25", "1.8", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, "gradle")
+		if err != nil {
+			t.Errorf("ParseNative(%q, gradle) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("ParseNative(%q, gradle).Contains(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseGradleRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{"", "1+", "1.a.+", "[1.0]", "[,]", "[,2.0]", "[1.0,]", "[1.0,2.0,3.0]", "1.0 2.0", "!!"} {
+		if _, err := ParseNative(constraint, "gradle"); err == nil {
+			t.Errorf("ParseNative(%q, gradle) expected error", constraint)
+		}
+	}
+}
+
+func TestParseGradleRichVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rich    GradleRichVersion
+		version string
+		want    bool
+	}{
+		{"strictly range", GradleRichVersion{Strictly: "[1.0,2.0["}, "1.5", true},
+		{"strictly excludes", GradleRichVersion{Strictly: "[1.0,2.0["}, "2.0", false},
+		{"strictly version", GradleRichVersion{Strictly: "1.5"}, "1.6", false},
+		{"require is a lower bound", GradleRichVersion{Require: "1.5"}, "3.0", true},
+		{"require excludes lower", GradleRichVersion{Require: "1.5"}, "1.4", false},
+		{"require range keeps lower bound", GradleRichVersion{Require: "[1.5,2.0["}, "2.5", true},
+		{"strictly and require", GradleRichVersion{Strictly: "[1.0,2.0[", Require: "1.4"}, "1.2", false},
+		{"strictly and require within", GradleRichVersion{Strictly: "[1.0,2.0[", Require: "1.4"}, "1.8", true},
+		{"prefer does not narrow", GradleRichVersion{Prefer: "1.5"}, "0.1", true},
+		{"reject version", GradleRichVersion{Strictly: "[1.0,2.0[", Reject: []string{"1.5"}}, "1.5", false},
+		{"reject version keeps others", GradleRichVersion{Strictly: "[1.0,2.0[", Reject: []string{"1.5"}}, "1.6", true},
+		{"reject range", GradleRichVersion{Require: "1.0", Reject: []string{"[1.2,1.4]"}}, "1.3", false},
+		{"reject range upper edge", GradleRichVersion{Require: "1.0", Reject: []string{"[1.2,1.4]"}}, "1.4", false},
+		{"reject range keeps others", GradleRichVersion{Require: "1.0", Reject: []string{"[1.2,1.4]"}}, "1.4.1", true},
+		{"reject prefix", GradleRichVersion{Reject: []string{"1.+"}}, "1.3", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseGradleRichVersion(tt.rich)
+		if err != nil {
+			t.Errorf("%s: ParseGradleRichVersion error: %v", tt.name, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("%s: Contains(%q) = %v, want %v", tt.name, tt.version, got, tt.want)
+		}
+	}
+
+	if _, err := ParseGradleRichVersion(GradleRichVersion{Reject: []string{"[1.0"}}); err == nil {
+		t.Error("ParseGradleRichVersion with an invalid reject expected error")
+	}
+}
+
+func TestGradleSchemeComparison(t *testing.T) {
+	t.Parallel()
+
+	// Ascending, from Gradle's documented version ordering examples.
+	ordered := []string{
+		"1.0-dev-1", "1.0-alpha-1", "1.0-rc-1", "1.0-snapshot", "1.0-final", "1.0-ga", "1.0-release", "1.0-sp-1", "1.0", "1.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		if got := CompareWithScheme(ordered[i-1], ordered[i], "gradle"); got != -1 {
+			t.Errorf("CompareWithScheme(%q, %q, gradle) = %d, want -1", ordered[i-1], ordered[i], got)
+		}
+	}
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.1", "1.1.0", -1},
+		{"1.1.a", "1.1", -1},
+		{"1.10", "1.9", 1},
+		{"1.a", "1.1", -1},
+		{"1.0rc1", "1.0-rc-1", 0},
+		{"1.0-RC1", "1.0-rc1", 0},
+		{"1.0-alpha", "1.0-beta", -1},
+		{"1.0-Beta", "1.0-alpha", -1},
+		{"1.0-SNAPSHOT", "1.0-RC", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareWithScheme(tt.a, tt.b, "gradle"); got != tt.want {
+			t.Errorf("CompareWithScheme(%q, %q, gradle) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
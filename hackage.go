@@ -0,0 +1,276 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cabal build-depends version ranges.
+// https://cabal.readthedocs.io/en/stable/cabal-package-description-file.html#pkg-field-build-depends
+//
+// Grammar, with && binding tighter than ||:
+//
+//	range := conj ("||" conj)*
+//	conj  := atom ("&&" atom)*
+//	atom  := "(" range ")" | "-any" | "-none" | op version | op "{" version ("," version)* "}"
+//	op    := "==" | ">=" | "<=" | ">" | "<" | "^>="
+//
+// An == version may end in .* to match every version with that prefix.
+
+const (
+	hackageTokenOperator = iota
+	hackageTokenVersion
+	hackageTokenPunct
+)
+
+type hackageToken struct {
+	kind  int
+	value string
+}
+
+// hackageParser is a recursive-descent parser over a tokenized Cabal range.
+type hackageParser struct {
+	input  string
+	tokens []hackageToken
+	pos    int
+}
+
+// parseHackageRange parses Cabal's build-depends version range syntax.
+func (p *Parser) parseHackageRange(constraint string) (*Range, error) {
+	tokens, err := tokenizeHackageConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty hackage constraint")
+	}
+	parser := &hackageParser{input: constraint, tokens: tokens}
+	r, err := parser.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos != len(tokens) {
+		return nil, parser.errorf("unexpected %q", tokens[parser.pos].value)
+	}
+	return r, nil
+}
+
+// tokenizeHackageConstraint splits a Cabal range into operators, versions and
+// punctuation. Whitespace is insignificant between tokens.
+func tokenizeHackageConstraint(constraint string) ([]hackageToken, error) {
+	var tokens []hackageToken
+	for i := 0; i < len(constraint); {
+		c := constraint[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == '{' || c == '}' || c == ',':
+			tokens = append(tokens, hackageToken{kind: hackageTokenPunct, value: constraint[i : i+1]})
+			i++
+		case strings.HasPrefix(constraint[i:], "&&"), strings.HasPrefix(constraint[i:], "||"):
+			tokens = append(tokens, hackageToken{kind: hackageTokenPunct, value: constraint[i : i+2]})
+			i += 2
+		case strings.HasPrefix(constraint[i:], "-any"), strings.HasPrefix(constraint[i:], "-none"):
+			end := i + 1
+			for end < len(constraint) && isASCIIAlpha(constraint[end]) {
+				end++
+			}
+			if keyword := constraint[i:end]; keyword != "-any" && keyword != "-none" {
+				return nil, fmt.Errorf("invalid hackage constraint: %s", constraint)
+			}
+			tokens = append(tokens, hackageToken{kind: hackageTokenOperator, value: constraint[i:end]})
+			i = end
+		case c == '^' || c == '=' || c == '>' || c == '<':
+			operator := hackageOperatorAt(constraint[i:])
+			if operator == "" {
+				return nil, fmt.Errorf("invalid hackage operator in constraint: %s", constraint)
+			}
+			tokens = append(tokens, hackageToken{kind: hackageTokenOperator, value: operator})
+			i += len(operator)
+		case isASCIIDigit(c):
+			end := i
+			for end < len(constraint) && (isASCIIDigit(constraint[end]) || constraint[end] == '.' || constraint[end] == '*') {
+				end++
+			}
+			tokens = append(tokens, hackageToken{kind: hackageTokenVersion, value: constraint[i:end]})
+			i = end
+		default:
+			return nil, fmt.Errorf("invalid hackage constraint: %s", constraint)
+		}
+	}
+	return tokens, nil
+}
+
+func hackageOperatorAt(s string) string {
+	for _, operator := range []string{"^>=", "==", ">=", "<=", ">", "<"} {
+		if strings.HasPrefix(s, operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+func (hp *hackageParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid hackage constraint %q: %s", hp.input, fmt.Sprintf(format, args...))
+}
+
+func (hp *hackageParser) peek(value string) bool {
+	return hp.pos < len(hp.tokens) && hp.tokens[hp.pos].kind != hackageTokenVersion && hp.tokens[hp.pos].value == value
+}
+
+func (hp *hackageParser) parseUnion() (*Range, error) {
+	result, err := hp.parseIntersection()
+	if err != nil {
+		return nil, err
+	}
+	for hp.peek("||") {
+		hp.pos++
+		next, err := hp.parseIntersection()
+		if err != nil {
+			return nil, err
+		}
+		result = result.Union(next)
+	}
+	return result, nil
+}
+
+func (hp *hackageParser) parseIntersection() (*Range, error) {
+	result, err := hp.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for hp.peek("&&") {
+		hp.pos++
+		next, err := hp.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		result = result.Intersect(next)
+	}
+	return result, nil
+}
+
+func (hp *hackageParser) parseAtom() (*Range, error) {
+	if hp.pos >= len(hp.tokens) {
+		return nil, hp.errorf("unexpected end of constraint")
+	}
+	token := hp.tokens[hp.pos]
+	hp.pos++
+	switch {
+	case token.kind == hackageTokenPunct && token.value == "(":
+		r, err := hp.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if !hp.peek(")") {
+			return nil, hp.errorf("missing closing parenthesis")
+		}
+		hp.pos++
+		return r, nil
+	case token.kind == hackageTokenOperator && token.value == "-any":
+		return rangeWithScheme(Unbounded(), schemeHackage), nil
+	case token.kind == hackageTokenOperator && token.value == "-none":
+		return rangeWithScheme(Empty(), schemeHackage), nil
+	case token.kind == hackageTokenOperator:
+		return hp.parseOperand(token.value)
+	default:
+		return nil, hp.errorf("unexpected %q", token.value)
+	}
+}
+
+// parseOperand parses the version or version set following an operator.
+func (hp *hackageParser) parseOperand(operator string) (*Range, error) {
+	if hp.peek("{") {
+		if operator != "==" && operator != "^>=" {
+			return nil, hp.errorf("version sets require == or ^>=, not %s", operator)
+		}
+		hp.pos++
+		var result *Range
+		for {
+			if hp.pos >= len(hp.tokens) || hp.tokens[hp.pos].kind != hackageTokenVersion {
+				return nil, hp.errorf("expected version in set")
+			}
+			r, err := hackageComparatorRange(operator, hp.tokens[hp.pos].value)
+			if err != nil {
+				return nil, err
+			}
+			hp.pos++
+			if result == nil {
+				result = r
+			} else {
+				result = result.Union(r)
+			}
+			if hp.peek(",") {
+				hp.pos++
+				continue
+			}
+			if hp.peek("}") {
+				hp.pos++
+				return result, nil
+			}
+			return nil, hp.errorf("unterminated version set")
+		}
+	}
+	if hp.pos >= len(hp.tokens) || hp.tokens[hp.pos].kind != hackageTokenVersion {
+		return nil, hp.errorf("expected version after %s", operator)
+	}
+	version := hp.tokens[hp.pos].value
+	hp.pos++
+	return hackageComparatorRange(operator, version)
+}
+
+// hackageComparatorRange expands one Cabal comparator. ^>= x.y.z means
+// >= x.y.z && < x.(y+1), and == x.y.* means >= x.y && < x.(y+1).
+func hackageComparatorRange(operator, version string) (*Range, error) {
+	if strings.HasSuffix(version, ".*") {
+		prefix := strings.TrimSuffix(version, ".*")
+		if operator != "==" || !intDotVersionRegex.MatchString(prefix) {
+			return nil, fmt.Errorf("invalid hackage wildcard: %s%s", operator, version)
+		}
+		parts := strings.Split(prefix, ".")
+		parts[len(parts)-1] = incNumStr(parts[len(parts)-1])
+		upper := strings.Join(parts, ".")
+		return rangeWithScheme(NewRange([]Interval{NewInterval(prefix, upper, true, false)}), schemeHackage), nil
+	}
+	if !intDotVersionRegex.MatchString(version) {
+		return nil, fmt.Errorf("invalid hackage version: %s", version)
+	}
+
+	var interval Interval
+	switch operator {
+	case "==":
+		interval = ExactInterval(version)
+	case ">=":
+		interval = GreaterThanInterval(version, true)
+	case ">":
+		interval = GreaterThanInterval(version, false)
+	case "<=":
+		interval = LessThanInterval(version, true)
+	case "<":
+		interval = LessThanInterval(version, false)
+	case "^>=":
+		interval = NewInterval(version, hackageMajorUpperBound(version), true, false)
+	default:
+		return nil, fmt.Errorf("invalid hackage operator: %s", operator)
+	}
+	return rangeWithScheme(NewRange([]Interval{interval}), schemeHackage), nil
+}
+
+// hackageMajorUpperBound returns the next PVP major version, which is formed
+// from the first two components. A single component gains a minor of 1.
+func hackageMajorUpperBound(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) == 1 {
+		return parts[0] + ".1"
+	}
+	return parts[0] + "." + incNumStr(parts[1])
+}
+
+// compareHackage orders Cabal versions as lists of integers, so a shorter
+// version sorts before a longer one it prefixes: 1.0 < 1.0.0.
+func compareHackage(a, b string) int {
+	if c := compareIntDot(a, b); c != 0 {
+		return c
+	}
+	return cmpInt(strings.Count(a, "."), strings.Count(b, "."))
+}
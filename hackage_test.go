@@ -0,0 +1,91 @@
+package vers
+
+import "testing"
+
+func TestParseHackageRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"^>=1.2.3", "1.2.3", true},
+		{"^>=1.2.3", "1.2.9.1", true},
+		{"^>=1.2.3", "1.3", false},
+		{"^>=1.2.3", "1.2.2", false},
+		{"^>=1", "1.0.5", true},
+		{"^>=1", "1.1", false},
+		{"==1.2.*", "1.2", true},
+		{"==1.2.*", "1.2.7", true},
+		{"==1.2.*", "1.3", false},
+		{"==1.2.*", "1.1.9", false},
+		{"==1.2", "1.2", true},
+		{"==1.2", "1.2.0", false},
+		{">=1 && <1.5", "1.4.9", true},
+		{">=1 && <1.5", "1.5", false},
+		{">=1&&<1.5", "0.9", false},
+		{"<1 || >=2", "0.5", true},
+		{"<1 || >=2", "1.5", false},
+		{"<1 || >=2", "2", true},
+		{">=1 && <2 || >=3 && <4", "3.1", true},
+		{">=1 && <2 || >=3 && <4", "2.5", false},
+		{">=1 && (<2 || >=3)", "3.1", true},
+		{">=1 && (<2 || >=3)", "0.5", false},
+		{"(>=1 && <2) || ==3.*", "3.9", true},
+		{"== { 1.2, 1.4 }", "1.4", true},
+		{"== { 1.2, 1.4 }", "1.3", false},
+		{"^>= { 1.2, 2.0 }", "2.0.3", true},
+		{"^>= { 1.2, 2.0 }", "1.3", false},
+		{"-any", "7.0", true},
+		{"-none", "7.0", false},
+		{">=1 && -none", "1.0", false},
+		{"-none || ==2", "2", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, "hackage")
+		if err != nil {
+			t.Errorf("ParseNative(%q, hackage) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("ParseNative(%q, hackage).Contains(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseHackageRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{
+		"", "1.2", ">=", ">=1 &&", "(>=1", ">=1)", ">=1.2.*", "==1.*.2", "!=1.2",
+		"~>1.2", "-anything", "== { 1.2", "== { }", ">= { 1.2 }", ">=1 <2", ">=1.2-beta",
+	} {
+		if _, err := ParseNative(constraint, "hackage"); err == nil {
+			t.Errorf("ParseNative(%q, hackage) expected error", constraint)
+		}
+	}
+}
+
+func TestHackageSchemeComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0.0", -1},
+		{"1.0.0", "1", 1},
+		{"1.10", "1.9", 1},
+		{"1.2.3", "1.2.3", 0},
+		{"2", "1.99.99", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareWithScheme(tt.a, tt.b, "hackage"); got != tt.want {
+			t.Errorf("CompareWithScheme(%q, %q, hackage) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !ValidWithScheme("1.2.3.4", "hackage") || ValidWithScheme("1.2-beta", "hackage") {
+		t.Error("hackage versions must be dotted integers")
+	}
+}
@@ -1,6 +1,9 @@
 package vers
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestHighestSatisfying_Npm(t *testing.T) {
 	versions := []string{"1.0.0", "1.5.0", "2.0.0", "2.5.0", "3.0.0"}
@@ -72,3 +75,86 @@ func TestHighestSatisfying_Empty(t *testing.T) {
 		t.Errorf("got %q, want empty", got)
 	}
 }
+
+func TestLowestSatisfying(t *testing.T) {
+	versions := []string{"2.5.0", "1.5.0", "not-a-version", "1.0.0", "2.0.0"}
+	cases := []struct {
+		constraint string
+		want       string
+	}{
+		{"^1.0", "1.0.0"},
+		{">1.0.0", "1.5.0"},
+		{"^2.0.0", "2.0.0"},
+		{"^4.0.0", ""},
+	}
+	for _, tc := range cases {
+		got, err := LowestSatisfying(versions, tc.constraint, "npm")
+		if err != nil {
+			t.Errorf("LowestSatisfying(%q): %v", tc.constraint, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("LowestSatisfying(%q) = %q, want %q", tc.constraint, got, tc.want)
+		}
+	}
+
+	if got, _ := LowestSatisfying([]string{"v1.3.0", "v1.2.0", "v1.10.0"}, "vers:golang/>=v1.2.1", ""); got != "v1.3.0" {
+		t.Errorf("LowestSatisfying with vers URI = %q, want v1.3.0", got)
+	}
+}
+
+func TestFilterSatisfying(t *testing.T) {
+	versions := []string{"2.0.0", "1.5.0", "0.9.0", "1.0.0", "1.2.0-rc.1"}
+	got, err := FilterSatisfying(versions, ">=1.0.0-rc.1 <2.0.0", "npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.5.0", "1.0.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSatisfying = %v, want %v", got, want)
+	}
+
+	got, err = FilterSatisfying(versions, "^3.0.0", "npm")
+	if err != nil || got != nil {
+		t.Errorf("FilterSatisfying with no match = %v, %v; want nil, nil", got, err)
+	}
+
+	if _, err := FilterSatisfying(versions, "vers:npm", ""); err == nil {
+		t.Error("FilterSatisfying with an invalid constraint expected error")
+	}
+}
+
+func TestSatisfyingPreferStable(t *testing.T) {
+	versions := []string{"1.0.0", "1.1.0rc1", "1.1.0", "1.2.0b1"}
+
+	cases := []struct {
+		name       string
+		constraint string
+		pick       func([]string, string, string, ...SelectOption) (string, error)
+		opts       []SelectOption
+		want       string
+	}{
+		{"highest includes prereleases", ">=1.0", HighestSatisfying, nil, "1.2.0b1"},
+		{"highest prefers stable", ">=1.0", HighestSatisfying, []SelectOption{PreferStable()}, "1.1.0"},
+		{"highest falls back to prereleases", ">=1.1.1", HighestSatisfying, []SelectOption{PreferStable()}, "1.2.0b1"},
+		{"lowest includes prereleases", ">1.0", LowestSatisfying, nil, "1.1.0rc1"},
+		{"lowest prefers stable", ">1.0", LowestSatisfying, []SelectOption{PreferStable()}, "1.1.0"},
+	}
+	for _, tc := range cases {
+		got, err := tc.pick(versions, tc.constraint, "pypi", tc.opts...)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	got, err := FilterSatisfying(versions, ">=1.0", "pypi", PreferStable())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.0.0", "1.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSatisfying with PreferStable = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package vers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MavenVersionRange is a parsed Maven version specification, as produced by
+// maven-artifact's VersionRange.createFromVersionSpec.
+type MavenVersionRange struct {
+	// Range holds the versions the specification allows, using the maven
+	// scheme. A soft requirement allows every version.
+	Range *Range
+	// Recommended is the version of a soft requirement such as 1.0, which
+	// Maven prefers but does not require. It is empty for bracket ranges.
+	Recommended string
+}
+
+// ParseMavenVersionRange parses a Maven version specification: a soft
+// requirement such as 1.0, or one or more comma-separated bracket ranges
+// such as [1.0], [1.0,2.0) or (,1.0],[1.2,). As in Maven, the ranges must
+// be in ascending order without overlapping. Comparators such as >=1.0 are
+// rejected; ParseNative accepts them alongside Maven's syntax.
+func ParseMavenVersionRange(spec string) (*MavenVersionRange, error) {
+	remaining := strings.TrimSpace(spec)
+	if remaining == "" {
+		return nil, fmt.Errorf("empty maven version range")
+	}
+
+	var intervals []Interval
+	for strings.HasPrefix(remaining, "[") || strings.HasPrefix(remaining, "(") {
+		end := strings.IndexAny(remaining, ")]")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid maven version range: unbounded range: %s", spec)
+		}
+		interval, err := parseMavenRestriction(remaining[:end+1], spec)
+		if err != nil {
+			return nil, err
+		}
+		if len(intervals) > 0 {
+			previous := intervals[len(intervals)-1].Max
+			if interval.Min == "" || previous == "" || compareMaven(interval.Min, previous) < 0 {
+				return nil, fmt.Errorf("invalid maven version range: ranges overlap: %s", spec)
+			}
+		}
+		intervals = append(intervals, interval)
+		remaining = strings.TrimSpace(remaining[end+1:])
+		if strings.HasPrefix(remaining, ",") {
+			remaining = strings.TrimSpace(remaining[1:])
+		}
+	}
+
+	if remaining == "" {
+		return &MavenVersionRange{Range: rangeWithScheme(NewRange(intervals), schemeMaven)}, nil
+	}
+	if len(intervals) > 0 {
+		return nil, fmt.Errorf("invalid maven version range: only bracket ranges allowed in a set: %s", spec)
+	}
+	if strings.ContainsAny(remaining, "[](), \t") {
+		return nil, fmt.Errorf("invalid maven version: %s", remaining)
+	}
+	// Comparators such as >=1.0 are not Maven syntax; ParseNative reads them.
+	if operator, _ := extractOperator(remaining); operator != "" {
+		return nil, fmt.Errorf("invalid maven version range: comparator %s is not maven syntax: %s", operator, spec)
+	}
+	return &MavenVersionRange{Range: rangeWithScheme(Unbounded(), schemeMaven), Recommended: remaining}, nil
+}
+
+// parseMavenRestriction parses one bracket range such as [1.0,2.0) or [1.0].
+func parseMavenRestriction(restriction, spec string) (Interval, error) {
+	minInclusive := restriction[0] == '['
+	maxInclusive := restriction[len(restriction)-1] == ']'
+	inner := strings.TrimSpace(restriction[1 : len(restriction)-1])
+
+	lower, upper, hasComma := strings.Cut(inner, ",")
+	if !hasComma {
+		if !minInclusive || !maxInclusive || inner == "" {
+			return Interval{}, fmt.Errorf("invalid maven version range: single version must be surrounded by []: %s", spec)
+		}
+		return ExactInterval(inner), nil
+	}
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	if strings.ContainsAny(lower+upper, ",[]() \t") {
+		return Interval{}, fmt.Errorf("invalid maven version range: %s", spec)
+	}
+	if lower != "" && upper != "" {
+		comparison := compareMaven(upper, lower)
+		if comparison < 0 || comparison == 0 && (!minInclusive || !maxInclusive) {
+			return Interval{}, fmt.Errorf("invalid maven version range: range defies version ordering: %s", spec)
+		}
+	}
+	return NewInterval(lower, upper, minInclusive && lower != "", maxInclusive && upper != ""), nil
+}
+
+// IsSoft reports whether the specification is a soft requirement, which
+// recommends a version but matches any.
+func (m *MavenVersionRange) IsSoft() bool {
+	return m.Recommended != ""
+}
+
+// String returns the specification in Maven syntax: the recommended version
+// of a soft requirement, or the bracket ranges.
+func (m *MavenVersionRange) String() string {
+	if m.IsSoft() {
+		return m.Recommended
+	}
+	return FormatMaven(m.Range)
+}
+
+// FormatMaven returns a range in Maven's bracket syntax, such as
+// [1.0,2.0) or (,1.0],[1.2,). Maven has no exclusion operator, so
+// exclusions split the ranges around them: excluding 1.5 from every
+// version gives (,1.5),(1.5,). An empty range has no Maven form and
+// formats as "".
+func FormatMaven(r *Range) string {
+	var intervals []Interval
+	for _, interval := range r.Intervals {
+		for _, piece := range splitExcludedInterval(interval, r.Exclusions, compareMaven) {
+			if !piece.isEmptyCmp(compareMaven) {
+				intervals = append(intervals, piece)
+			}
+		}
+	}
+	slices.SortStableFunc(intervals, func(a, b Interval) int {
+		switch {
+		case a.Min == b.Min:
+			return 0
+		case a.Min == "":
+			return -1
+		case b.Min == "":
+			return 1
+		}
+		return compareMaven(a.Min, b.Min)
+	})
+
+	parts := make([]string, len(intervals))
+	for i, interval := range intervals {
+		parts[i] = formatMavenInterval(interval)
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatMavenInterval writes one interval as a Maven bracket range.
+func formatMavenInterval(interval Interval) string {
+	if interval.Min != "" && interval.Min == interval.Max && interval.MinInclusive && interval.MaxInclusive {
+		return "[" + interval.Min + "]"
+	}
+	var b strings.Builder
+	if interval.Min != "" && interval.MinInclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	b.WriteString(interval.Min + "," + interval.Max)
+	if interval.Max != "" && interval.MaxInclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// splitExcludedInterval removes excluded versions from an interval,
+// splitting it into the pieces on either side of each one.
+func splitExcludedInterval(interval Interval, exclusions []string, cmp func(a, b string) int) []Interval {
+	pieces := []Interval{interval}
+	for _, excluded := range exclusions {
+		var next []Interval
+		for _, piece := range pieces {
+			if !piece.containsCmp(excluded, cmp) {
+				next = append(next, piece)
+				continue
+			}
+			next = append(next,
+				NewInterval(piece.Min, excluded, piece.MinInclusive, false),
+				NewInterval(excluded, piece.Max, false, piece.MaxInclusive))
+		}
+		pieces = next
+	}
+	return pieces
+}
@@ -0,0 +1,118 @@
+package vers
+
+import "testing"
+
+func TestParseMavenVersionRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec        string
+		recommended string
+		want        string
+	}{
+		{"1.0", "1.0", "(,)"},
+		{" 1.0-SNAPSHOT ", "1.0-SNAPSHOT", "(,)"},
+		{"[1.0]", "", "[1.0]"},
+		{"[1.0,2.0)", "", "[1.0,2.0)"},
+		{"[ 1.0 , 2.0 ]", "", "[1.0,2.0]"},
+		{"(,1.0]", "", "(,1.0]"},
+		{"[1.5,)", "", "[1.5,)"},
+		{"(,1.0],[1.2,)", "", "(,1.0],[1.2,)"},
+		{"(,1.1),(1.1,)", "", "(,1.1),(1.1,)"},
+		{"[1.0,1.2) , [1.2,2.0)", "", "[1.0,1.2),[1.2,2.0)"},
+		{"[1.0],[1.1],[1.2]", "", "[1.0],[1.1],[1.2]"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMavenVersionRange(tt.spec)
+		if err != nil {
+			t.Errorf("ParseMavenVersionRange(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if m.Recommended != tt.recommended || m.IsSoft() != (tt.recommended != "") {
+			t.Errorf("ParseMavenVersionRange(%q) recommended = %q, want %q", tt.spec, m.Recommended, tt.recommended)
+		}
+		if got := FormatMaven(m.Range); got != tt.want {
+			t.Errorf("FormatMaven(ParseMavenVersionRange(%q)) = %q, want %q", tt.spec, got, tt.want)
+		}
+		wantString := tt.want
+		if tt.recommended != "" {
+			wantString = tt.recommended
+		}
+		if got := m.String(); got != wantString {
+			t.Errorf("ParseMavenVersionRange(%q).String() = %q, want %q", tt.spec, got, wantString)
+		}
+	}
+}
+
+func TestParseMavenVersionRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"",
+		"[1.0",
+		"(1.0)",
+		"[]",
+		"[2.0,1.0]",
+		"(1.0,1.0]",
+		"[1.0,2.0],[1.5,3.0]",
+		"[1.2,),[1.0,1.1]",
+		"[1.0,2.0),(,3.0]",
+		"[1.0,2.0],3.0",
+		"[1.0,2.0,3.0]",
+		"1.0 2.0",
+		">=1.0",
+		"<2.0",
+		"=1.0",
+		"!=1.5",
+	} {
+		if m, err := ParseMavenVersionRange(spec); err == nil {
+			t.Errorf("ParseMavenVersionRange(%q) = %v, expected error", spec, m.Range)
+		}
+	}
+}
+
+func TestMavenVersionRangeContains(t *testing.T) {
+	t.Parallel()
+
+	m, err := ParseMavenVersionRange("(,1.0],[1.2,2.0-alpha)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for version, want := range map[string]bool{
+		"0.5": true, "1.0": true, "1.0.0": true, "1.1": false, "1.2": true, "1.9": true, "2.0-alpha": false, "2.0": false,
+	} {
+		if got := m.Range.Contains(version); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestFormatMaven(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		r    *Range
+		want string
+	}{
+		{Unbounded(), "(,)"},
+		{&Range{}, ""},
+		{Unbounded().Exclude("1.5"), "(,1.5),(1.5,)"},
+		{NewRange([]Interval{NewInterval("1.0", "2.0", true, false)}).Exclude("1.5").Exclude("1.7"), "[1.0,1.5),(1.5,1.7),(1.7,2.0)"},
+		{NewRange([]Interval{NewInterval("1.0", "2.0", true, true)}).Exclude("1.0"), "(1.0,2.0]"},
+		{NewRange([]Interval{ExactInterval("1.0")}).Exclude("1.0"), ""},
+		{NewRange([]Interval{GreaterThanInterval("3.0", true), LessThanInterval("1.0", false)}), "(,1.0),[3.0,)"},
+	}
+	for _, tt := range tests {
+		if got := FormatMaven(tt.r); got != tt.want {
+			t.Errorf("FormatMaven(%v) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+
+	r, err := ParseNative(">=1.0|!=1.5", "maven")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FormatMaven(r); got != "[1.0,1.5),(1.5,)" {
+		t.Errorf("FormatMaven(ParseNative(>=1.0|!=1.5)) = %q", got)
+	}
+}
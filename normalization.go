@@ -26,7 +26,15 @@ func validVersionForScheme(version, scheme string) bool { //nolint:gocyclo
 		return validComposerVersion(version)
 	case schemePub:
 		return validPubVersion(version)
-	case schemeSemVer, schemeNPM, schemeCargo, schemeGo, schemeGolang, schemeHex, schemeElixir:
+	case schemeGo, schemeGolang:
+		// Go versions carry a v; parseGoVersion also rejects pseudo-versions
+		// with impossible timestamps. Bare versions are accepted as semver.
+		if strings.HasPrefix(version, "v") {
+			_, ok := parseGoVersion(version)
+			return ok
+		}
+		return validSemverLike(version)
+	case schemeSemVer, schemeNPM, schemeCargo, schemeHex, schemeElixir:
 		return validSemverLike(version)
 	case schemeGem, schemeRubyGems:
 		return gemVersionRegex.MatchString(version)
@@ -36,11 +44,19 @@ func validVersionForScheme(version, scheme string) bool { //nolint:gocyclo
 		return validRPMVersion(version)
 	case schemeNuGet:
 		return nugetVersionRegex.MatchString(version)
-	case schemeIntDot:
+	case schemeIntDot, schemeHackage:
 		return intDotVersionRegex.MatchString(version)
 	case schemeOpenSSL:
 		_, ok := parseOpenSSLVersion(version)
 		return ok
+	case schemeConda:
+		_, ok := parseCondaVersion(version)
+		return ok
+	case schemeTerraform, schemeOpenTofu:
+		_, ok := parseTerraformVersion(version)
+		return ok
+	case schemeGradle:
+		return validGradleVersion(version)
 	case schemeMaven, schemeLexicographic, schemeDatetime, schemeAPK, schemeAlpine, schemeGentoo, schemeALPM, schemeConan:
 		return !strings.ContainsAny(version, " \t\r\n")
 	default:
@@ -65,10 +81,17 @@ func normalizeVersionForScheme(version, scheme string) (string, error) {
 		return normalizeComposerVersion(version), nil
 	case schemePub:
 		return normalizePubVersion(version), nil
+	case schemeConda:
+		normalized, _ := normalizeCondaVersion(version)
+		return normalized, nil
+	case schemeTerraform, schemeOpenTofu:
+		return normalizeTerraformVersion(version), nil
+	case schemeNuGet:
+		return normalizeNuGetVersion(version), nil
 	case schemeSemVer, schemeNPM, schemeCargo, schemeGo, schemeGolang, schemeHex, schemeElixir:
 		return normalizeSemverLike(version, scheme == schemeGo || scheme == schemeGolang), nil
-	case schemeGem, schemeRubyGems, schemeDeb, schemeDebian, schemeRPM, schemeNuGet, schemeIntDot, schemeOpenSSL,
-		schemeMaven, schemeLexicographic, schemeDatetime, schemeAPK, schemeAlpine, schemeGentoo, schemeALPM, schemeConan:
+	case schemeGem, schemeRubyGems, schemeDeb, schemeDebian, schemeRPM, schemeIntDot, schemeHackage, schemeOpenSSL,
+		schemeMaven, schemeGradle, schemeLexicographic, schemeDatetime, schemeAPK, schemeAlpine, schemeGentoo, schemeALPM, schemeConan:
 		return version, nil
 	default:
 		return Normalize(version)
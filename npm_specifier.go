@@ -0,0 +1,288 @@
+package vers
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// NpmSpecifierKind classifies a package.json dependency value.
+type NpmSpecifierKind int
+
+const (
+	// NpmSpecRange is a semver range such as ^1.2.0 or an exact version.
+	NpmSpecRange NpmSpecifierKind = iota
+	// NpmSpecTag is a dist-tag such as latest or next.
+	NpmSpecTag
+	// NpmSpecAlias installs another package under this name, as in npm:other@^1.
+	NpmSpecAlias
+	// NpmSpecGit is a git repository, including hosted shorthands such as
+	// github:user/repo and user/repo.
+	NpmSpecGit
+	// NpmSpecFile is a local directory or tarball, including link: specs.
+	NpmSpecFile
+	// NpmSpecWorkspace is a workspace: spec resolved within a monorepo.
+	NpmSpecWorkspace
+	// NpmSpecURL is a remote tarball URL.
+	NpmSpecURL
+)
+
+// String returns the lowercase name of the kind.
+func (k NpmSpecifierKind) String() string {
+	switch k {
+	case NpmSpecRange:
+		return "range"
+	case NpmSpecTag:
+		return "tag"
+	case NpmSpecAlias:
+		return "alias"
+	case NpmSpecGit:
+		return "git"
+	case NpmSpecFile:
+		return "file"
+	case NpmSpecWorkspace:
+		return "workspace"
+	case NpmSpecURL:
+		return "url"
+	}
+	return fmt.Sprintf("NpmSpecifierKind(%d)", int(k))
+}
+
+// NpmSpecifier is a classified package.json dependency value.
+type NpmSpecifier struct {
+	// Raw is the specifier as given.
+	Raw  string
+	Kind NpmSpecifierKind
+	// Range is the semver range the specifier selects, if any: the range
+	// itself, an alias target's range, a workspace range or a git #semver: range.
+	Range *Range
+	// Tag is the dist-tag of a tag or of an alias to a tag.
+	Tag string
+	// Name is the target package of an alias.
+	Name string
+	// Protocol is the prefix that selected the kind, without its colon, such
+	// as npm, workspace, file, link, github or git+https.
+	Protocol string
+	// Location is the path, URL or repository the specifier points at. For a
+	// workspace specifier that is not a range, such as workspace:^, it holds
+	// the text after the protocol.
+	Location string
+	// Committish is the git ref after #, other than a #semver: range.
+	Committish string
+}
+
+var (
+	// npmHostedShorthandRegex matches user/repo GitHub shorthands.
+	npmHostedShorthandRegex = regexp.MustCompile(`^[^@%/\s.-][^:@%/\s]*/[^@\s/%#]+(?:#.*)?$`)
+	// npmSCPGitRegex matches scp-like git remotes such as git@host:user/repo.git.
+	npmSCPGitRegex = regexp.MustCompile(`^[^@/:\s]+@[^:/\s]+\.[^:/\s]+:[^\s]+$`)
+	// npmTarballRegex matches local tarball file names.
+	npmTarballRegex = regexp.MustCompile(`(?i)\.(?:tgz|tar\.gz|tar)$`)
+)
+
+var npmGitProtocols = []string{"git+ssh", "git+https", "git+http", "git+file", "git"}
+
+var npmHostedGitProtocols = []string{"github", "gitlab", "bitbucket", "gist"}
+
+var npmHostedGitHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// ParseNpmSpecifier classifies a package.json dependency value and extracts
+// the semver range it carries, following npm's package argument rules.
+// Aliases only accept a range or tag target.
+func ParseNpmSpecifier(spec string) (*NpmSpecifier, error) {
+	s := strings.TrimSpace(spec)
+	result := &NpmSpecifier{Raw: spec}
+	protocol, rest, hasProtocol := strings.Cut(s, ":")
+	if !hasProtocol || strings.ContainsAny(protocol, "/@ ") {
+		protocol, rest = "", s
+	}
+
+	switch {
+	case protocol == "npm":
+		return parseNpmAlias(result, rest)
+	case protocol == "workspace":
+		result.Kind, result.Protocol = NpmSpecWorkspace, protocol
+		// workspace:^ and workspace:~ take the range from the workspace
+		// package's own version when publishing.
+		if r, ok := parseStrictNpmRange(rest); ok && rest != "^" && rest != "~" {
+			result.Range = r
+		} else {
+			result.Location = rest
+		}
+		return result, nil
+	case protocol == "file" || protocol == "link":
+		result.Kind, result.Protocol, result.Location = NpmSpecFile, protocol, rest
+		return result, nil
+	case slices.Contains(npmGitProtocols, protocol):
+		result.Kind, result.Protocol = NpmSpecGit, protocol
+		return parseNpmGitFragment(result, s)
+	case slices.Contains(npmHostedGitProtocols, protocol):
+		result.Kind, result.Protocol = NpmSpecGit, protocol
+		return parseNpmGitFragment(result, rest)
+	case protocol == "http" || protocol == "https":
+		if isNpmHostedGitURL(s) {
+			result.Kind, result.Protocol = NpmSpecGit, protocol
+			return parseNpmGitFragment(result, s)
+		}
+		result.Kind, result.Protocol, result.Location = NpmSpecURL, protocol, s
+		return result, nil
+	case npmSCPGitRegex.MatchString(s):
+		result.Kind, result.Protocol = NpmSpecGit, "ssh"
+		return parseNpmGitFragment(result, s)
+	case npmHostedShorthandRegex.MatchString(s):
+		result.Kind, result.Protocol = NpmSpecGit, "github"
+		return parseNpmGitFragment(result, s)
+	case isNpmFileSpec(s):
+		result.Kind, result.Location = NpmSpecFile, s
+		return result, nil
+	case protocol != "":
+		return nil, fmt.Errorf("unsupported npm specifier protocol: %s", protocol)
+	}
+
+	if err := parseNpmRegistrySpec(result, s); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseNpmAlias parses the name@spec after npm:.
+func parseNpmAlias(result *NpmSpecifier, target string) (*NpmSpecifier, error) {
+	result.Kind, result.Protocol = NpmSpecAlias, "npm"
+	name, sub := target, ""
+	// A scoped name starts with @, so look for the version separator after it.
+	if i := strings.LastIndexByte(target, '@'); i > 0 {
+		name, sub = target[:i], target[i+1:]
+	}
+	if !isNpmPackageName(name) {
+		return nil, fmt.Errorf("invalid npm alias target: %s", target)
+	}
+	result.Name = name
+	// npm resolves a bare npm:name to its latest dist-tag, as it does for
+	// a bare package name.
+	if sub == "" {
+		result.Tag = "latest"
+		return result, nil
+	}
+	if err := parseNpmRegistrySpec(result, sub); err != nil {
+		return nil, fmt.Errorf("invalid npm alias target: %s", target)
+	}
+	return result, nil
+}
+
+// parseNpmRegistrySpec sets a range or a dist-tag on result.
+func parseNpmRegistrySpec(result *NpmSpecifier, spec string) error {
+	if r, ok := parseStrictNpmRange(spec); ok {
+		result.Range = r
+		return nil
+	}
+	if !isNpmTag(spec) {
+		return fmt.Errorf("invalid npm specifier: %s", spec)
+	}
+	if result.Kind == NpmSpecRange {
+		result.Kind = NpmSpecTag
+	}
+	result.Tag = spec
+	return nil
+}
+
+// parseNpmGitFragment splits location#fragment. The fragment holds a
+// committish or a semver: range, optionally followed by ::key:value parts.
+func parseNpmGitFragment(result *NpmSpecifier, location string) (*NpmSpecifier, error) {
+	location, fragment, _ := strings.Cut(location, "#")
+	if location == "" {
+		return nil, fmt.Errorf("invalid npm git specifier: %s", result.Raw)
+	}
+	result.Location = location
+	for _, part := range strings.Split(fragment, "::") {
+		if constraint, ok := strings.CutPrefix(part, "semver:"); ok {
+			r, ok := parseStrictNpmRange(constraint)
+			if !ok {
+				return nil, fmt.Errorf("invalid npm git semver range: %s", constraint)
+			}
+			result.Range = r
+			continue
+		}
+		if part != "" && !strings.Contains(part, ":") {
+			result.Committish = part
+		}
+	}
+	if result.Range != nil && result.Committish != "" {
+		return nil, fmt.Errorf("npm git specifier has both a committish and a semver range: %s", result.Raw)
+	}
+	return result, nil
+}
+
+// parseStrictNpmRange parses an npm range, rejecting text such as tag names
+// that the lenient range parser would read as versions.
+func parseStrictNpmRange(spec string) (*Range, bool) {
+	r, err := ParseNative(spec, schemeNPM)
+	if err != nil {
+		return nil, false
+	}
+	for _, intervals := range [][]Interval{r.Intervals, r.RawConstraints} {
+		for _, interval := range intervals {
+			for _, bound := range []string{interval.Min, interval.Max} {
+				if bound != "" && !validVersionForScheme(bound, schemeNPM) {
+					return nil, false
+				}
+			}
+		}
+	}
+	for _, exclusion := range r.Exclusions {
+		if !validVersionForScheme(exclusion, schemeNPM) {
+			return nil, false
+		}
+	}
+	return r, true
+}
+
+// isNpmTag reports whether tag only uses characters that encodeURIComponent
+// leaves alone, which npm requires of dist-tags.
+func isNpmTag(tag string) bool {
+	return tag != "" && containsOnly(tag, func(c byte) bool {
+		return isASCIIAlnum(c) || strings.IndexByte("-_.!~*'()", c) >= 0
+	})
+}
+
+// isNpmPackageName reports whether name is a plain or @scope/ package name.
+func isNpmPackageName(name string) bool {
+	if scoped, ok := strings.CutPrefix(name, "@"); ok {
+		scope, pkg, found := strings.Cut(scoped, "/")
+		return found && isNpmNamePart(scope) && isNpmNamePart(pkg)
+	}
+	return isNpmNamePart(name)
+}
+
+func isNpmNamePart(part string) bool {
+	return part != "" && part[0] != '.' && part[0] != '_' && url.PathEscape(part) == part
+}
+
+// isNpmFileSpec reports whether spec is a local path or tarball.
+func isNpmFileSpec(spec string) bool {
+	switch {
+	case strings.HasPrefix(spec, "."), strings.HasPrefix(spec, "~/"),
+		strings.HasPrefix(spec, "/"), strings.HasPrefix(spec, `\`):
+		return true
+	case len(spec) >= 2 && isLetter(spec[0]) && spec[1] == ':':
+		// A Windows drive letter.
+		return true
+	}
+	return npmTarballRegex.MatchString(spec)
+}
+
+// isNpmHostedGitURL reports whether an http(s) URL points at a repository
+// on a known git host rather than at a tarball.
+func isNpmHostedGitURL(spec string) bool {
+	u, err := url.Parse(spec)
+	if err != nil || !npmHostedGitHosts[strings.ToLower(u.Hostname())] {
+		return false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	return len(segments) == 2 && !npmTarballRegex.MatchString(u.Path) //nolint:mnd
+}
@@ -0,0 +1,103 @@
+package vers
+
+import "testing"
+
+func TestParseNpmSpecifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec       string
+		kind       NpmSpecifierKind
+		rangeStr   string
+		tag        string
+		name       string
+		protocol   string
+		location   string
+		committish string
+	}{
+		{spec: "^1.2.0", kind: NpmSpecRange, rangeStr: "vers:npm/>=1.2.0|<2.0.0"},
+		{spec: "", kind: NpmSpecRange, rangeStr: "vers:npm/*"},
+		{spec: "1.x || >=3", kind: NpmSpecRange, rangeStr: "vers:npm/>=1.0.0|<2.0.0|>=3.0.0"},
+		{spec: "latest", kind: NpmSpecTag, tag: "latest"},
+		{spec: "next", kind: NpmSpecTag, tag: "next"},
+		{spec: "npm:other@^1", kind: NpmSpecAlias, name: "other", protocol: "npm", rangeStr: "vers:npm/>=1.0.0|<2.0.0"},
+		{spec: "npm:@scope/pkg@beta", kind: NpmSpecAlias, name: "@scope/pkg", protocol: "npm", tag: "beta"},
+		{spec: "npm:other", kind: NpmSpecAlias, name: "other", protocol: "npm", tag: "latest"},
+		{spec: "npm:@scope/pkg", kind: NpmSpecAlias, name: "@scope/pkg", protocol: "npm", tag: "latest"},
+		{spec: "workspace:^", kind: NpmSpecWorkspace, protocol: "workspace", location: "^"},
+		{spec: "workspace:*", kind: NpmSpecWorkspace, protocol: "workspace", rangeStr: "vers:npm/*"},
+		{spec: "workspace:^1.2.0", kind: NpmSpecWorkspace, protocol: "workspace", rangeStr: "vers:npm/>=1.2.0|<2.0.0"},
+		{spec: "file:../x", kind: NpmSpecFile, protocol: "file", location: "../x"},
+		{spec: "link:", kind: NpmSpecFile, protocol: "link"},
+		{spec: "../x", kind: NpmSpecFile, location: "../x"},
+		{spec: "~/pkgs/x.tgz", kind: NpmSpecFile, location: "~/pkgs/x.tgz"},
+		{spec: "pkg-1.0.0.tgz", kind: NpmSpecFile, location: "pkg-1.0.0.tgz"},
+		{
+			spec: "git+https://github.com/user/repo.git#semver:^1.2", kind: NpmSpecGit,
+			protocol: "git+https", location: "git+https://github.com/user/repo.git", rangeStr: "vers:npm/>=1.2.0|<2.0.0",
+		},
+		{
+			spec: "git+ssh://git@github.com/user/repo.git#main", kind: NpmSpecGit,
+			protocol: "git+ssh", location: "git+ssh://git@github.com/user/repo.git", committish: "main",
+		},
+		{spec: "github:user/repo#v1", kind: NpmSpecGit, protocol: "github", location: "user/repo", committish: "v1"},
+		{spec: "user/repo", kind: NpmSpecGit, protocol: "github", location: "user/repo"},
+		{
+			spec: "user/repo#semver:~2.0::path:packages/a", kind: NpmSpecGit,
+			protocol: "github", location: "user/repo", rangeStr: "vers:npm/>=2.0.0|<2.1.0",
+		},
+		{spec: "git@github.com:user/repo.git", kind: NpmSpecGit, protocol: "ssh", location: "git@github.com:user/repo.git"},
+		{spec: "https://github.com/user/repo", kind: NpmSpecGit, protocol: "https", location: "https://github.com/user/repo"},
+		{
+			spec: "https://registry.example.com/pkg/-/pkg-1.0.0.tgz", kind: NpmSpecURL,
+			protocol: "https", location: "https://registry.example.com/pkg/-/pkg-1.0.0.tgz",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNpmSpecifier(tt.spec)
+		if err != nil {
+			t.Errorf("ParseNpmSpecifier(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got.Kind != tt.kind || got.Tag != tt.tag || got.Name != tt.name || got.Protocol != tt.protocol ||
+			got.Location != tt.location || got.Committish != tt.committish || got.Raw != tt.spec {
+			t.Errorf("ParseNpmSpecifier(%q) = %+v", tt.spec, got)
+		}
+		var rangeStr string
+		if got.Range != nil {
+			rangeStr = ToVersString(got.Range, "npm")
+		}
+		if rangeStr != tt.rangeStr {
+			t.Errorf("ParseNpmSpecifier(%q).Range = %q, want %q", tt.spec, rangeStr, tt.rangeStr)
+		}
+	}
+}
+
+func TestParseNpmSpecifierErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"foo@1",
+		"npm:",
+		"npm:other@not a tag",
+		"github:user/repo#semver:latest",
+		"github:user/repo#main::semver:^1",
+		"svn:repo",
+	} {
+		if got, err := ParseNpmSpecifier(spec); err == nil {
+			t.Errorf("ParseNpmSpecifier(%q) = %+v, expected error", spec, got)
+		}
+	}
+}
+
+func TestNpmSpecifierKindString(t *testing.T) {
+	t.Parallel()
+
+	if got := NpmSpecAlias.String(); got != "alias" {
+		t.Errorf("NpmSpecAlias.String() = %q", got)
+	}
+	if got := NpmSpecifierKind(99).String(); got != "NpmSpecifierKind(99)" {
+		t.Errorf("NpmSpecifierKind(99).String() = %q", got)
+	}
+}
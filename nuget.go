@@ -0,0 +1,211 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NuGet version ranges and floating versions.
+// https://learn.microsoft.com/en-us/nuget/concepts/package-versioning#version-ranges
+// https://learn.microsoft.com/en-us/nuget/concepts/dependency-resolution#floating-versions
+//
+// A plain version is a minimum, brackets and parentheses set inclusive and
+// exclusive bounds, and [1.0] is an exact match. A * floats the part it
+// replaces:
+//
+//	*          >= 0.0.0
+//	1.*        >= 1.0.0 < 2.0.0
+//	1.2.*      >= 1.2.0 < 1.3.0
+//	1.*-*      the same, starting from the 1.0.0 prereleases
+//	1.0.0-*    1.0.0 and its prereleases
+//	1.0.0-rc*  1.0.0 and prereleases whose label starts with rc
+//	*-*        every version
+//
+// As in NuGet's VersionRange.Satisfies, prereleases between the bounds of a
+// float match; floating only decides which version resolution prefers.
+
+// parseNugetRange parses NuGet version ranges and floating versions.
+func (p *Parser) parseNugetRange(s string) (*Range, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty nuget constraint")
+	}
+	if strings.Contains(s, "*") {
+		return parseNugetFloatRange(s)
+	}
+	if strings.HasPrefix(s, "[") || strings.HasPrefix(s, "(") {
+		return parseNugetBracketRange(s)
+	}
+	if !nugetVersionRegex.MatchString(s) {
+		return nil, fmt.Errorf("invalid nuget version: %s", s)
+	}
+	return NewRange([]Interval{GreaterThanInterval(s, true)}), nil
+}
+
+func parseNugetBracketRange(s string) (*Range, error) {
+	if !strings.HasSuffix(s, "]") && !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid nuget range: %s", s)
+	}
+	minInclusive, maxInclusive := s[0] == '[', s[len(s)-1] == ']'
+	lower, upper, hasComma := strings.Cut(s[1:len(s)-1], ",")
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+
+	if !hasComma {
+		// Only [1.0] is an exact match; (1.0) and [1.0) are invalid.
+		if !minInclusive || !maxInclusive || !nugetVersionRegex.MatchString(lower) {
+			return nil, fmt.Errorf("invalid nuget range: %s", s)
+		}
+		return Exact(lower), nil
+	}
+	if lower == "" && upper == "" {
+		return nil, fmt.Errorf("invalid nuget range: %s", s)
+	}
+	for _, bound := range []string{lower, upper} {
+		if bound != "" && !nugetVersionRegex.MatchString(bound) {
+			return nil, fmt.Errorf("invalid nuget version in range: %s", s)
+		}
+	}
+	if lower != "" && upper != "" {
+		c := compareNuGet(lower, upper)
+		if c > 0 || (c == 0 && (!minInclusive || !maxInclusive)) {
+			return nil, fmt.Errorf("invalid nuget range: %s", s)
+		}
+	}
+	return NewRange([]Interval{NewInterval(lower, upper, minInclusive, maxInclusive)}), nil
+}
+
+// parseNugetFloatRange expands a floating version into the versions it can
+// resolve to.
+func parseNugetFloatRange(s string) (*Range, error) {
+	release, label, hasLabel := strings.Cut(s, "-")
+	labelFloat := hasLabel && strings.HasSuffix(label, "*")
+	labelPrefix := strings.TrimSuffix(label, "*")
+	if hasLabel && (!labelFloat || strings.Contains(labelPrefix, "*") || !validNugetLabelPrefix(labelPrefix)) {
+		return nil, fmt.Errorf("invalid nuget floating version: %s", s)
+	}
+
+	if !strings.Contains(release, "*") {
+		// Only the prerelease label floats: 1.0.0-* or 1.0.0-rc*.
+		if !labelFloat || !nugetVersionRegex.MatchString(release) {
+			return nil, fmt.Errorf("invalid nuget floating version: %s", s)
+		}
+		return nugetLabelFloatRange(release, labelPrefix), nil
+	}
+
+	parts := strings.Split(release, ".")
+	if len(parts) > 4 || parts[len(parts)-1] != "*" { //nolint:mnd
+		return nil, fmt.Errorf("invalid nuget floating version: %s", s)
+	}
+	fixed := parts[:len(parts)-1]
+	for _, part := range fixed {
+		if !isDigits(part) {
+			return nil, fmt.Errorf("invalid nuget floating version: %s", s)
+		}
+	}
+
+	lower := nugetFloatBase(fixed)
+	upper := ""
+	if len(fixed) > 0 {
+		next := append([]string(nil), fixed...)
+		next[len(next)-1] = incNumStr(next[len(next)-1])
+		// -0 is the lowest prerelease, so the next version's prereleases
+		// stay out of the float.
+		upper = nugetFloatBase(next) + "-0"
+	}
+	switch {
+	case labelFloat && labelPrefix == "":
+		lower += "-0"
+	case labelFloat:
+		lower += "-" + labelPrefix
+	}
+	if upper == "" {
+		if labelFloat && labelPrefix == "" {
+			return NewRange([]Interval{UnboundedInterval()}), nil
+		}
+		return NewRange([]Interval{GreaterThanInterval(lower, true)}), nil
+	}
+	return NewRange([]Interval{NewInterval(lower, upper, true, false)}), nil
+}
+
+// nugetFloatBase pads the fixed parts of a float to at least three parts.
+func nugetFloatBase(parts []string) string {
+	padded := append([]string(nil), parts...)
+	for len(padded) < 3 { //nolint:mnd
+		padded = append(padded, "0")
+	}
+	return strings.Join(padded, ".")
+}
+
+// nugetLabelFloatRange matches a version's stable release and those of its
+// prereleases whose label starts with prefix. Labels compare
+// case-insensitively, so the prefix's last character is bumped to find the
+// first label past every label that starts with it.
+func nugetLabelFloatRange(version, prefix string) *Range {
+	if prefix == "" {
+		return NewRange([]Interval{NewInterval(version+"-0", version, true, true)})
+	}
+	prefix = strings.ToLower(prefix)
+	var labels Interval
+	if strings.HasSuffix(prefix, ".") {
+		// Every identifier sorts at or above 0 and every label that starts
+		// with "rc." sorts below "rc-".
+		identifier := strings.TrimSuffix(prefix, ".")
+		labels = NewInterval(version+"-"+prefix+"0", version+"-"+identifier+"-", true, false)
+	} else {
+		last := prefix[len(prefix)-1] + 1
+		if last == '.' {
+			last = '0'
+		}
+		labels = NewInterval(version+"-"+prefix, version+"-"+prefix[:len(prefix)-1]+string(last), true, false)
+	}
+	return NewRange([]Interval{labels, ExactInterval(version)})
+}
+
+// validNugetLabelPrefix reports whether a floating label prefix can be
+// bounded. The last identifier must not be all digits, since numeric
+// identifiers sort by value rather than by prefix.
+func validNugetLabelPrefix(prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	if strings.HasPrefix(prefix, ".") {
+		return false
+	}
+	identifiers := strings.Split(prefix, ".")
+	for i, identifier := range identifiers {
+		if identifier == "" && i != len(identifiers)-1 {
+			return false
+		}
+		if !containsOnly(identifier, func(c byte) bool { return isASCIIAlnum(c) || c == '-' }) {
+			return false
+		}
+	}
+	last := identifiers[len(identifiers)-1]
+	if last == "" && len(identifiers) > 1 {
+		last = identifiers[len(identifiers)-2]
+	}
+	return !isDigits(last)
+}
+
+// normalizeNuGetVersion formats a version the way NuGet.Versioning's
+// ToNormalizedString does: at least three numeric parts without leading
+// zeros, a revision only when it is not zero, and no build metadata.
+func normalizeNuGetVersion(version string) string {
+	release, _, _ := strings.Cut(version, "+")
+	release, label, hasLabel := strings.Cut(release, "-")
+	parts := strings.Split(release, ".")
+	for i := range parts {
+		parts[i] = trimLeadingZeros(parts[i])
+	}
+	for len(parts) < 3 { //nolint:mnd
+		parts = append(parts, "0")
+	}
+	if len(parts) == 4 && parts[3] == "0" { //nolint:mnd
+		parts = parts[:3]
+	}
+	normalized := strings.Join(parts, ".")
+	if hasLabel {
+		normalized += "-" + label
+	}
+	return normalized
+}
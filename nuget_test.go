@@ -0,0 +1,111 @@
+package vers
+
+import "testing"
+
+func TestParseNugetFloatingRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"*", "0.0.1", true},
+		{"*", "99.0.0", true},
+		{"*", "1.0.0-beta", true},
+		{"*", "0.0.0-alpha", false},
+		{"*-*", "0.0.0-alpha", true},
+		{"1.*", "1.0.0", true},
+		{"1.*", "1.9.9.9", true},
+		{"1.*", "2.0.0", false},
+		{"1.*", "2.0.0-beta", false},
+		{"1.*", "0.9.0", false},
+		{"1.*", "1.0.0-beta", false},
+		{"1.2.*", "1.2", true},
+		{"1.2.*", "1.2.7", true},
+		{"1.2.*", "1.3.0", false},
+		{"1.2.3.*", "1.2.3.4", true},
+		{"1.2.3.*", "1.2.4", false},
+		{"1.*-*", "1.0.0-beta", true},
+		{"1.*-*", "1.5.0-rc.1", true},
+		{"1.*-*", "2.0.0-alpha", false},
+		{"1.0.0-*", "1.0.0-alpha", true},
+		{"1.0.0-*", "1.0.0", true},
+		{"1.0.0-*", "1.0.1-alpha", false},
+		{"1.0.0-*", "0.9.0", false},
+		{"1.0.0-rc*", "1.0.0-rc", true},
+		{"1.0.0-rc*", "1.0.0-RC.2", true},
+		{"1.0.0-rc*", "1.0.0-rc2", true},
+		{"1.0.0-rc*", "1.0.0-beta", false},
+		{"1.0.0-rc*", "1.0.0-rd", false},
+		{"1.0.0-rc*", "1.0.0", true},
+		{"1.0.0-rc.*", "1.0.0-rc.1", true},
+		{"1.0.0-rc.*", "1.0.0-rc.beta", true},
+		{"1.0.0-rc.*", "1.0.0-rc", false},
+		{"1.0.0-rc.*", "1.0.0-rc-1", false},
+		{"1.0.0-rc-*", "1.0.0-rc-final", true},
+		{"1.0.0-rc-*", "1.0.0-rc0", false},
+		{"[1.0, 2.0)", "1.5", true},
+		{" [1.0 , 2.0 ) ", "2.0", false},
+		{"[1.0]", "1.0.0", true},
+		{"[1.0]", "1.0.1", false},
+		{"(, 2.0]", "2.0", true},
+		{"1.0", "3.0", true},
+		{"1.0", "0.9", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, "nuget")
+		if err != nil {
+			t.Errorf("ParseNative(%q, nuget) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("ParseNative(%q, nuget).Contains(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseNugetRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{
+		"", "(1.0)", "[1.0)", "(,)", "[2.0,1.0]", "(1.0,1.0]", "[1.0,2.0", "1.2*", "*.1", "1.*.1",
+		"1.0.0-*-*", "1.0.0-rc.1*", "1.0.0-.*", "1.2.3.4.*", "a.*", "v1.0",
+	} {
+		if _, err := ParseNative(constraint, "nuget"); err == nil {
+			t.Errorf("ParseNative(%q, nuget) expected error", constraint)
+		}
+	}
+}
+
+func TestNormalizeNuGetVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1", "1.0.0"},
+		{"1.0", "1.0.0"},
+		{"1.0.0.0", "1.0.0"},
+		{"1.0.0.1", "1.0.0.1"},
+		{"01.02.003", "1.2.3"},
+		{"1.2.3.00", "1.2.3"},
+		{"1.0-Beta.1", "1.0.0-Beta.1"},
+		{"1.0.0+sha.abc", "1.0.0"},
+		{"1.0.0.0-rc+build", "1.0.0-rc"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeWithScheme(tt.input, "nuget")
+		if err != nil {
+			t.Errorf("NormalizeWithScheme(%q, nuget) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeWithScheme(%q, nuget) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+	if _, err := NormalizeWithScheme("1.0.0.0.0", "nuget"); err == nil {
+		t.Error("NormalizeWithScheme(1.0.0.0.0, nuget) expected error")
+	}
+}
@@ -148,6 +148,8 @@ func (p *Parser) parseNative(constraint string, scheme string) (*Range, error) {
 		return p.parsePubRange(constraint)
 	case schemeMaven:
 		return p.parseMavenRange(constraint)
+	case schemeGradle:
+		return p.parseGradleRange(constraint)
 	case schemeNuGet:
 		return p.parseNugetRange(constraint)
 	case schemeCargo:
@@ -166,6 +168,12 @@ func (p *Parser) parseNative(constraint string, scheme string) (*Range, error) {
 		return p.parseOpenSSLRange(constraint)
 	case schemeNginx:
 		return p.parseNginxRange(constraint)
+	case schemeHackage:
+		return p.parseHackageRange(constraint)
+	case schemeConda:
+		return p.parseCondaRange(constraint)
+	case schemeTerraform, schemeOpenTofu:
+		return p.parseTerraformRange(constraint)
 	default:
 		return p.parseConstraints(constraint, scheme)
 	}
@@ -935,88 +943,27 @@ func (p *Parser) parsePypiCompatibleRelease(version string) (*Range, error) {
 func (p *Parser) parseMavenRange(s string) (*Range, error) {
 	s = strings.TrimSpace(s)
 
-	// Bracket notation
-	if (strings.HasPrefix(s, "[") || strings.HasPrefix(s, "(")) &&
-		(strings.HasSuffix(s, "]") || strings.HasSuffix(s, ")")) {
-		return p.parseBracketRange(s)
+	// Comparator syntax such as >=1.0 is accepted alongside Maven's own.
+	if operator, _ := extractOperator(s); operator != "" {
+		return p.parseConstraints(s, schemeMaven)
 	}
 
-	// Simple version (minimum version in Maven)
-	if matched, _ := regexp.MatchString(`^[0-9]`, s); matched {
-		return NewRange([]Interval{
-			GreaterThanInterval(s, true),
-		}), nil
-	}
-
-	return p.parseConstraints(s, schemeMaven)
-}
-
-func (p *Parser) parseBracketRange(s string) (*Range, error) {
-	minInclusive := s[0] == '['
-	maxInclusive := s[len(s)-1] == ']'
-
-	inner := s[1 : len(s)-1]
-	parts := strings.SplitN(inner, ",", 2) //nolint:mnd
-
-	if len(parts) == 1 {
-		// Exact version: [1.0]
-		return Exact(strings.TrimSpace(parts[0])), nil
-	}
-
-	min := strings.TrimSpace(parts[0])
-	max := strings.TrimSpace(parts[1])
-
-	interval := Interval{
-		Min:          min,
-		Max:          max,
-		MinInclusive: minInclusive,
-		MaxInclusive: maxInclusive,
-	}
-
-	if min == "" {
-		interval.Min = ""
-	}
-	if max == "" {
-		interval.Max = ""
+	// Bracket ranges and sets of them, or a soft requirement that
+	// recommends a version but matches any.
+	m, err := ParseMavenVersionRange(s)
+	if err != nil {
+		return nil, err
 	}
-
-	return NewRange([]Interval{interval}), nil
-}
-
-// nuget: same as maven
-func (p *Parser) parseNugetRange(s string) (*Range, error) {
-	return p.parseMavenRange(s)
+	return m.Range, nil
 }
 
 // cargo: ^1.2.3, ~1.2.3, >=1.0.0
 func (p *Parser) parseCargoRange(s string) (*Range, error) {
-	s = strings.TrimSpace(s)
-	if strings.Contains(s, ",") {
-		var result *Range
-		for _, part := range strings.Split(s, ",") {
-			r, err := p.parseCargoRange(part)
-			if err != nil {
-				return nil, err
-			}
-			if result == nil {
-				result = r
-			} else {
-				result = result.Intersect(r)
-			}
-		}
-		return result, nil
-	}
-	if s != "" && !strings.ContainsAny(s, "^~*<>=") {
-		return p.parseCaretRange(s)
-	}
-	if operator, version := extractOperator(s); operator == "<" && isPartialNpmVersion(version) {
-		lower, _, err := npmPartialBounds(version)
-		if err != nil {
-			return nil, err
-		}
-		return NewRange([]Interval{NewInterval("", lower+"-0", false, false)}), nil
+	req, err := ParseCargoVersionReq(s)
+	if err != nil {
+		return nil, err
 	}
-	return p.parseNpmRange(s)
+	return req.Range(), nil
 }
 
 // go: >=1.0.0, <2.0.0
@@ -1025,11 +972,17 @@ func (p *Parser) parseGoRange(s string) (*Range, error) {
 	if strings.Contains(s, ",") {
 		parts := strings.Split(s, ",")
 		var result *Range
+		var exclusions []string
 		for _, part := range parts {
 			constraint, err := parseConstraintWithScheme(strings.TrimSpace(part), schemeGo)
 			if err != nil {
 				return nil, err
 			}
+			constraint.Version = goCanonicalVersion(constraint.Version)
+			if constraint.IsExclusion() {
+				exclusions = append(exclusions, constraint.Version)
+				continue
+			}
 			interval, ok := constraint.ToInterval()
 			if !ok {
 				continue
@@ -1041,10 +994,37 @@ func (p *Parser) parseGoRange(s string) (*Range, error) {
 				result = result.Intersect(r)
 			}
 		}
+		if result == nil {
+			result = Unbounded()
+		}
+		result.Exclusions = append(result.Exclusions, exclusions...)
+		result.Scheme = schemeGo
 		return result, nil
 	}
 
-	return p.parseConstraints(s, schemeGo)
+	result, err := p.parseConstraints(s, schemeGo)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalGoRange(result), nil
+}
+
+// canonicalGoRange rewrites bare bounds and exclusions such as 1.0.0 to the
+// v form, so that they compare with the v-prefixed versions the go command
+// uses.
+func canonicalGoRange(r *Range) *Range {
+	for i := range r.Intervals {
+		r.Intervals[i].Min = goCanonicalVersion(r.Intervals[i].Min)
+		r.Intervals[i].Max = goCanonicalVersion(r.Intervals[i].Max)
+	}
+	for i := range r.RawConstraints {
+		r.RawConstraints[i].Min = goCanonicalVersion(r.RawConstraints[i].Min)
+		r.RawConstraints[i].Max = goCanonicalVersion(r.RawConstraints[i].Max)
+	}
+	for i, exclusion := range r.Exclusions {
+		r.Exclusions[i] = goCanonicalVersion(exclusion)
+	}
+	return r
 }
 
 // hex/elixir: ~> 1.2.3, >= 1.0.0 and < 2.0.0, ~> 1.0 or ~> 2.0
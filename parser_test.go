@@ -267,10 +267,15 @@ func TestParseMavenRange(t *testing.T) {
 		{"[1.0] exact match", "[1.0]", "1.0", true},
 		{"[1.0] excludes other", "[1.0]", "1.1", false},
 
-		// Simple version (minimum)
-		{"1.0 includes minimum", "1.0", "1.0", true},
+		// Soft requirement (recommended version, matches any)
+		{"1.0 includes recommended", "1.0", "1.0", true},
 		{"1.0 includes above", "1.0", "2.0.0", true},
-		{"1.0 excludes below", "1.0", "0.9.0", false},
+		{"1.0 includes below", "1.0", "0.9.0", true},
+
+		// Multiple ranges
+		{"(,1.0],[1.2,) includes below", "(,1.0],[1.2,)", "0.9", true},
+		{"(,1.0],[1.2,) excludes gap", "(,1.0],[1.2,)", "1.1", false},
+		{"(,1.0],[1.2,) includes above", "(,1.0],[1.2,)", "1.2", true},
 	}
 
 	parser := NewParser()
@@ -316,7 +321,7 @@ func TestParseNugetRange(t *testing.T) {
 }
 
 func TestParseCargoRange(t *testing.T) {
-	// Cargo uses npm-like syntax
+	// Cargo uses the semver crate's VersionReq syntax
 	tests := []struct {
 		name    string
 		input   string
@@ -358,6 +363,12 @@ func TestParseGoRange(t *testing.T) {
 		{">=1.0.0,<2.0.0 includes", ">=1.0.0,<2.0.0", "1.5.0", true},
 		{">=1.0.0,<2.0.0 excludes below", ">=1.0.0,<2.0.0", "0.9.0", false},
 		{">=1.0.0,<2.0.0 excludes above", ">=1.0.0,<2.0.0", "2.0.0", false},
+		{">=1.0.0,<2.0.0 includes v form", ">=1.0.0,<2.0.0", "v1.5.0", true},
+		{">=1.0.0,<2.0.0 excludes v form below", ">=1.0.0,<2.0.0", "v0.9.0", false},
+		{"!=1.0.0 excludes v form", ">=1.0.0, !=1.0.0", "v1.0.0", false},
+		{"!=1.0.0 excludes bare form", ">=1.0.0, !=1.0.0", "1.0.0", false},
+		{"!=v1.0.0 excludes bare form", ">=v1.0.0, !=v1.0.0", "1.0.0", false},
+		{"<2.0.0 excludes v form", "<2.0.0", "v2.0.0", false},
 	}
 
 	parser := NewParser()
@@ -0,0 +1,468 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PyPIRequirement is a parsed PEP 508 dependency specification, such as
+// requests[socks]>=2.8.1; python_version < "3.8".
+type PyPIRequirement struct {
+	Name   string
+	Extras []string
+	// URL is the direct reference after @, if any.
+	URL string
+	// Specifier is the version range. It is unbounded when the requirement
+	// has no version specifiers and nil when it uses a URL instead.
+	Specifier *Range
+	// Marker is the environment marker after ;, or nil when there is none.
+	Marker MarkerExpression
+}
+
+// Applies evaluates the requirement's marker against env. A requirement
+// without a marker always applies.
+func (r *PyPIRequirement) Applies(env map[string]string) (bool, error) {
+	if r.Marker == nil {
+		return true, nil
+	}
+	return r.Marker.Evaluate(env)
+}
+
+// MarkerExpression is a node of a parsed PEP 508 environment marker.
+type MarkerExpression interface {
+	// Evaluate reports whether the marker holds in env, which maps marker
+	// variables such as python_version to their values.
+	Evaluate(env map[string]string) (bool, error)
+	// String returns the marker in normalized form.
+	String() string
+}
+
+// MarkerAnd holds when both sides hold.
+type MarkerAnd struct {
+	Left, Right MarkerExpression
+}
+
+// MarkerOr holds when either side holds.
+type MarkerOr struct {
+	Left, Right MarkerExpression
+}
+
+// MarkerComparison compares two marker values, such as python_version < "3.8".
+type MarkerComparison struct {
+	Left     MarkerValue
+	Operator string
+	Right    MarkerValue
+}
+
+// MarkerValue is either an environment variable or a quoted string.
+type MarkerValue struct {
+	Variable string
+	Literal  string
+}
+
+// IsVariable reports whether the value names an environment variable.
+func (v MarkerValue) IsVariable() bool {
+	return v.Variable != ""
+}
+
+// String returns the variable name or the double-quoted literal.
+func (v MarkerValue) String() string {
+	if v.IsVariable() {
+		return v.Variable
+	}
+	if strings.Contains(v.Literal, `"`) {
+		return "'" + v.Literal + "'"
+	}
+	return `"` + v.Literal + `"`
+}
+
+// Evaluate reports whether both sides hold.
+func (m MarkerAnd) Evaluate(env map[string]string) (bool, error) {
+	left, err := m.Left.Evaluate(env)
+	if err != nil || !left {
+		return false, err
+	}
+	return m.Right.Evaluate(env)
+}
+
+// String joins both sides with and, parenthesizing or expressions.
+func (m MarkerAnd) String() string {
+	return markerOperand(m.Left) + " and " + markerOperand(m.Right)
+}
+
+func markerOperand(m MarkerExpression) string {
+	if _, ok := m.(MarkerOr); ok {
+		return "(" + m.String() + ")"
+	}
+	return m.String()
+}
+
+// Evaluate reports whether either side holds.
+func (m MarkerOr) Evaluate(env map[string]string) (bool, error) {
+	left, err := m.Left.Evaluate(env)
+	if err != nil || left {
+		return left, err
+	}
+	return m.Right.Evaluate(env)
+}
+
+// String joins both sides with or.
+func (m MarkerOr) String() string {
+	return m.Left.String() + " or " + m.Right.String()
+}
+
+// String returns the comparison with single spaces around the operator.
+func (m MarkerComparison) String() string {
+	return m.Left.String() + " " + m.Operator + " " + m.Right.String()
+}
+
+// Evaluate compares the two values. When the right side is a valid PEP 440
+// version for the operator and the left side is a valid version, they are
+// compared as a version specifier that admits prereleases, so
+// python_full_version >= "3.13" holds for 3.13.1 and 3.14.0rc1 but not for
+// 3.13.0rc1. Otherwise they are compared as strings. Extra names are
+// normalized before comparing.
+//
+// A python_full_version or implementation_version ending in + (a Python
+// built from an untagged checkout) is read as a local version, and
+// python_version defaults to the major and minor version of
+// python_full_version. extra defaults to the empty string.
+func (m MarkerComparison) Evaluate(env map[string]string) (bool, error) {
+	left, err := markerValue(m.Left, env)
+	if err != nil {
+		return false, err
+	}
+	right, err := markerValue(m.Right, env)
+	if err != nil {
+		return false, err
+	}
+	if m.Left.Variable == "extra" || m.Right.Variable == "extra" {
+		left, right = normalizeExtraName(left), normalizeExtraName(right)
+	}
+
+	if matched, ok := markerVersionMatch(left, m.Operator, right); ok {
+		return matched, nil
+	}
+	switch m.Operator {
+	case "==", "===":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	case ">=":
+		return left >= right, nil
+	case "in":
+		return strings.Contains(right, left), nil
+	case "not in":
+		return !strings.Contains(right, left), nil
+	}
+	return false, fmt.Errorf("cannot compare %q %s %q as strings", left, m.Operator, right)
+}
+
+func markerValue(value MarkerValue, env map[string]string) (string, error) {
+	if !value.IsVariable() {
+		return value.Literal, nil
+	}
+	if v, ok := env[value.Variable]; ok {
+		if value.Variable == "python_full_version" || value.Variable == "implementation_version" {
+			if strings.HasSuffix(v, "+") {
+				v += "local"
+			}
+		}
+		return v, nil
+	}
+	switch value.Variable {
+	case "extra":
+		return "", nil
+	case "python_version":
+		if full, ok := env["python_full_version"]; ok {
+			release, _ := splitNumericRelease(full)
+			if len(release) >= 2 { //nolint:mnd
+				return release[0] + "." + release[1], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("undefined marker variable: %s", value.Variable)
+}
+
+// markerVersionMatch compares left and right as PEP 440 versions. It
+// reports false for ok when either side is not a version for the operator.
+func markerVersionMatch(left, operator, right string) (matched bool, ok bool) {
+	if operator == "in" || operator == "not in" || operator == "===" {
+		return false, false
+	}
+	wildcard := strings.HasSuffix(right, ".*")
+	if wildcard && operator != "==" && operator != "!=" {
+		return false, false
+	}
+	if !validVersionForScheme(left, schemePyPI) ||
+		!validVersionForScheme(strings.TrimSuffix(right, ".*"), schemePyPI) {
+		return false, false
+	}
+	r, err := ParseNative(operator+right, schemePyPI)
+	if err != nil {
+		return false, false
+	}
+	if (operator == "==" || operator == "!=") && !strings.Contains(right, "+") {
+		// A specifier without a local version ignores the candidate's.
+		left, _, _ = strings.Cut(left, "+")
+	}
+	if _, _, excluded := excludedBy(r.Exclusions, left, schemePyPI, comparePyPI); excluded {
+		return false, true
+	}
+	for _, interval := range r.Intervals {
+		if contains, _ := pypiIntervalContains(interval, left); contains {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+var extraNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeExtraName applies PEP 685 extra name normalization.
+func normalizeExtraName(name string) string {
+	return extraNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+var pep508NameRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?`)
+
+var markerVariables = map[string]bool{
+	"os_name":                        true,
+	"sys_platform":                   true,
+	"platform_machine":               true,
+	"platform_python_implementation": true,
+	"platform_release":               true,
+	"platform_system":                true,
+	"platform_version":               true,
+	"python_version":                 true,
+	"python_full_version":            true,
+	"implementation_name":            true,
+	"implementation_version":         true,
+	"extra":                          true,
+}
+
+// ParsePyPIRequirement parses a PEP 508 dependency specification: a name,
+// optional [extras], then either version specifiers or @ and a URL, and
+// optionally ; and an environment marker.
+func ParsePyPIRequirement(requirement string) (*PyPIRequirement, error) {
+	s := strings.TrimSpace(requirement)
+	name := pep508NameRegex.FindString(s)
+	if name == "" {
+		return nil, fmt.Errorf("invalid pypi requirement: %s", requirement)
+	}
+	result := &PyPIRequirement{Name: name}
+	rest := strings.TrimSpace(s[len(name):])
+
+	if strings.HasPrefix(rest, "[") {
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid pypi requirement extras: %s", requirement)
+		}
+		if extras := strings.TrimSpace(rest[1:end]); extras != "" {
+			for _, extra := range strings.Split(extras, ",") {
+				extra = strings.TrimSpace(extra)
+				if extra == "" || pep508NameRegex.FindString(extra) != extra {
+					return nil, fmt.Errorf("invalid pypi requirement extra: %q", extra)
+				}
+				result.Extras = append(result.Extras, extra)
+			}
+		}
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+
+	var marker string
+	if strings.HasPrefix(rest, "@") {
+		// A URL may contain ;, so the marker must be separated by whitespace.
+		rest = strings.TrimSpace(rest[1:])
+		url, after, _ := strings.Cut(rest, " ")
+		after = strings.TrimSpace(after)
+		if url == "" || (after != "" && !strings.HasPrefix(after, ";")) {
+			return nil, fmt.Errorf("invalid pypi requirement URL: %s", requirement)
+		}
+		result.URL = url
+		marker = strings.TrimPrefix(after, ";")
+	} else {
+		specifier, after, hasMarker := strings.Cut(rest, ";")
+		if hasMarker && strings.TrimSpace(after) == "" {
+			return nil, fmt.Errorf("invalid pypi requirement marker: %s", requirement)
+		}
+		marker = after
+		specifier = strings.TrimSpace(specifier)
+		if strings.HasPrefix(specifier, "(") && strings.HasSuffix(specifier, ")") {
+			specifier = strings.TrimSpace(specifier[1 : len(specifier)-1])
+		}
+		if specifier == "" {
+			result.Specifier = Unbounded()
+			result.Specifier.Scheme = schemePyPI
+		} else {
+			r, err := ParseNative(specifier, schemePyPI)
+			if err != nil {
+				return nil, err
+			}
+			result.Specifier = r
+		}
+	}
+
+	if marker = strings.TrimSpace(marker); marker != "" {
+		m, err := ParseMarker(marker)
+		if err != nil {
+			return nil, err
+		}
+		result.Marker = m
+	}
+	return result, nil
+}
+
+// ParseMarker parses a PEP 508 environment marker such as
+// python_version < "3.8" and sys_platform != "win32".
+func ParseMarker(marker string) (MarkerExpression, error) {
+	p := &markerParser{input: marker}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("invalid marker: unexpected %q in %s", p.input[p.pos:], marker)
+	}
+	return expr, nil
+}
+
+type markerParser struct {
+	input string
+	pos   int
+}
+
+var markerOperators = []string{"===", "==", "!=", "<=", ">=", "~=", "<", ">"}
+
+func (p *markerParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// keyword consumes word when it appears as a whole word at the position.
+func (p *markerParser) keyword(word string) bool {
+	p.skipSpace()
+	if !strings.HasPrefix(p.input[p.pos:], word) {
+		return false
+	}
+	end := p.pos + len(word)
+	if end < len(p.input) && (isASCIIAlnum(p.input[end]) || p.input[end] == '_') {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *markerParser) parseOr() (MarkerExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = MarkerOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *markerParser) parseAnd() (MarkerExpression, error) {
+	left, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = MarkerAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *markerParser) parseExpr() (MarkerExpression, error) {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("invalid marker: missing ) in %s", p.input)
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	left, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	operator, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	right, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return MarkerComparison{Left: left, Operator: operator, Right: right}, nil
+}
+
+func (p *markerParser) parseOperator() (string, error) {
+	if p.keyword("in") {
+		return "in", nil
+	}
+	if p.keyword("not") {
+		if !p.keyword("in") {
+			return "", fmt.Errorf("invalid marker: expected in after not in %s", p.input)
+		}
+		return "not in", nil
+	}
+	for _, operator := range markerOperators {
+		if strings.HasPrefix(p.input[p.pos:], operator) {
+			p.pos += len(operator)
+			return operator, nil
+		}
+	}
+	return "", fmt.Errorf("invalid marker: expected an operator at %q in %s", p.input[p.pos:], p.input)
+}
+
+func (p *markerParser) parseValue() (MarkerValue, error) {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return MarkerValue{}, fmt.Errorf("invalid marker: unexpected end of %s", p.input)
+	}
+	if quote := p.input[p.pos]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(p.input[p.pos+1:], quote)
+		if end < 0 {
+			return MarkerValue{}, fmt.Errorf("invalid marker: unterminated string in %s", p.input)
+		}
+		literal := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2 //nolint:mnd
+		return MarkerValue{Literal: literal}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && (isASCIIAlnum(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+	if !markerVariables[name] {
+		return MarkerValue{}, fmt.Errorf("invalid marker variable: %q", name)
+	}
+	return MarkerValue{Variable: name}, nil
+}
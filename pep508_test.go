@@ -0,0 +1,190 @@
+package vers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePyPIRequirement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		name      string
+		extras    []string
+		url       string
+		specifier string
+		marker    string
+	}{
+		{
+			input:     `requests[socks]>=2.8.1,==2.8.*; python_version < "3.8" and sys_platform != "win32"`,
+			name:      "requests",
+			extras:    []string{"socks"},
+			specifier: "vers:pypi/>=2.8.dev0|>=2.8.1|<2.9.dev0",
+			marker:    `python_version < "3.8" and sys_platform != "win32"`,
+		},
+		{input: "django", name: "django", specifier: "vers:pypi/*"},
+		{input: "name (>=1.0, <2.0)", name: "name", specifier: "vers:pypi/>=1.0|<2.0"},
+		{input: "A.B-C_D [ x , y ] ~= 1.4", name: "A.B-C_D", extras: []string{"x", "y"}, specifier: "vers:pypi/>=1.4|<2"},
+		{input: "pkg[]", name: "pkg", specifier: "vers:pypi/*"},
+		{
+			input:  "pip @ https://example.com/pip-1.0.tar.gz#sha256=abc;x=1 ; os_name=='posix'",
+			name:   "pip",
+			url:    "https://example.com/pip-1.0.tar.gz#sha256=abc;x=1",
+			marker: `os_name == "posix"`,
+		},
+		{input: "pip@file:///tmp/pip", name: "pip", url: "file:///tmp/pip"},
+		{
+			input:     `foo; (python_version<'3' or extra=="test") and 'linux' in sys_platform`,
+			name:      "foo",
+			specifier: "vers:pypi/*",
+			marker:    `(python_version < "3" or extra == "test") and "linux" in sys_platform`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePyPIRequirement(tt.input)
+		if err != nil {
+			t.Errorf("ParsePyPIRequirement(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got.Name != tt.name || !reflect.DeepEqual(got.Extras, tt.extras) || got.URL != tt.url {
+			t.Errorf("ParsePyPIRequirement(%q) = %+v", tt.input, got)
+		}
+		var specifier string
+		if got.Specifier != nil {
+			specifier = ToVersString(got.Specifier, "pypi")
+		}
+		if specifier != tt.specifier {
+			t.Errorf("ParsePyPIRequirement(%q).Specifier = %q, want %q", tt.input, specifier, tt.specifier)
+		}
+		var marker string
+		if got.Marker != nil {
+			marker = got.Marker.String()
+		}
+		if marker != tt.marker {
+			t.Errorf("ParsePyPIRequirement(%q).Marker = %q, want %q", tt.input, marker, tt.marker)
+		}
+	}
+}
+
+func TestParsePyPIRequirementErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		"-foo",
+		"foo[bar",
+		"foo[b@r]",
+		"foo @",
+		"foo >=1.0;",
+		"foo; python_version",
+		"foo; python_version <",
+		"foo; unknown_var == '1'",
+		"foo; python_version < '3.8' and",
+		"foo; (python_version < '3.8'",
+		"foo; python_version < '3.8",
+		"foo; python_version not '3.8'",
+	} {
+		if got, err := ParsePyPIRequirement(input); err == nil {
+			t.Errorf("ParsePyPIRequirement(%q) = %+v, expected error", input, got)
+		}
+	}
+}
+
+func TestMarkerEvaluate(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"python_version":         "3.7",
+		"python_full_version":    "3.7.4",
+		"sys_platform":           "linux",
+		"os_name":                "posix",
+		"implementation_name":    "cpython",
+		"implementation_version": "3.7.4",
+		"platform_release":       "5.10.0-generic",
+	}
+
+	tests := []struct {
+		marker string
+		env    map[string]string
+		want   bool
+	}{
+		{`python_version < "3.8" and sys_platform != "win32"`, env, true},
+		{`python_version >= "3.8" or os_name == "nt"`, env, false},
+		{`python_version > "3.10"`, env, false}, // 3.7 < 3.10 as versions, not strings
+		{`python_version == "3.*"`, env, true},
+		{`python_version ~= "3.6"`, env, true},
+		{`python_full_version >= "3.7.4"`, env, true},
+		{`"3.8" > python_version`, env, true},
+		{`"linux" in sys_platform`, env, true},
+		{`"win" not in sys_platform`, env, true},
+		{`platform_release >= "5"`, env, true},
+		{`implementation_name === "cpython"`, env, true},
+		{`extra == "Test_Extra"`, map[string]string{"extra": "test-extra"}, true},
+		{`extra == "test"`, map[string]string{}, false},
+		// Prereleases of the running interpreter are compared as versions.
+		{`python_full_version >= "3.13"`, map[string]string{"python_full_version": "3.14.0rc1"}, true},
+		{`python_full_version >= "3.13"`, map[string]string{"python_full_version": "3.13.0rc1"}, false},
+		{`python_full_version < "3.13"`, map[string]string{"python_full_version": "3.13.0rc1"}, false},
+		// Untagged builds report a trailing + and compare as a local version.
+		{`python_full_version == "3.12.0"`, map[string]string{"python_full_version": "3.12.0+"}, true},
+		{`python_full_version > "3.12.0"`, map[string]string{"python_full_version": "3.12.0+"}, false},
+		{`implementation_version >= "3.12"`, map[string]string{"implementation_version": "3.12.0+"}, true},
+		// python_version falls back to python_full_version.
+		{`python_version == "3.11"`, map[string]string{"python_full_version": "3.11.2"}, true},
+	}
+
+	for _, tt := range tests {
+		m, err := ParseMarker(tt.marker)
+		if err != nil {
+			t.Fatalf("ParseMarker(%q) error: %v", tt.marker, err)
+		}
+		got, err := m.Evaluate(tt.env)
+		if err != nil {
+			t.Errorf("Evaluate(%q) error: %v", tt.marker, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.marker, got, tt.want)
+		}
+	}
+}
+
+func TestMarkerEvaluateErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, marker := range []string{
+		`sys_platform == "linux"`,
+		`os_name ~= "posix"`,
+	} {
+		m, err := ParseMarker(marker)
+		if err != nil {
+			t.Fatalf("ParseMarker(%q) error: %v", marker, err)
+		}
+		env := map[string]string{"os_name": "posix"}
+		if _, err := m.Evaluate(env); err == nil {
+			t.Errorf("Evaluate(%q) expected error", marker)
+		}
+	}
+}
+
+func TestPyPIRequirementApplies(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParsePyPIRequirement(`requests; sys_platform == "win32"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.Applies(map[string]string{"sys_platform": "linux"}); ok || err != nil {
+		t.Errorf("Applies on linux = %v, %v, want false", ok, err)
+	}
+
+	r, err = ParsePyPIRequirement("requests>=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.Applies(nil); !ok || err != nil {
+		t.Errorf("Applies without a marker = %v, %v, want true", ok, err)
+	}
+}
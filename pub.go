@@ -0,0 +1,256 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pubEmptyConstraint is how pub prints a constraint that allows nothing.
+const pubEmptyConstraint = "<empty>"
+
+// pubUnionRegex splits the alternatives of a pub union.
+var pubUnionRegex = regexp.MustCompile(`\s*\|\|\s*|\s+or\s+`)
+
+// PubVersionRange is one range of a pub constraint, like pub_semver's
+// VersionRange. Empty bounds are unbounded, and an exclusive upper bound
+// such as 2.0.0-0 is stored as pub stores it after parsing <2.0.0.
+type PubVersionRange struct {
+	Min, Max               string
+	IncludeMin, IncludeMax bool
+	// Caret marks a range written as ^Min, pub_semver's CompatibleWithRange.
+	Caret bool
+}
+
+// PubVersionConstraint is a pub version constraint: a union of ranges,
+// like pub_semver's VersionUnion. A single range or version has one entry,
+// any has one unbounded range and an empty constraint has none.
+type PubVersionConstraint struct {
+	Ranges []PubVersionRange
+}
+
+// ParsePubVersionConstraint parses a pub constraint such as ^1.2.3,
+// ">=1.0.0 <2.0.0" or any. Alternatives may be joined by || or by " or ",
+// the way pub prints unions in solver output; overlapping and adjacent
+// alternatives are merged as pub_semver's VersionUnion does.
+func ParsePubVersionConstraint(constraint string) (*PubVersionConstraint, error) {
+	alternatives, err := splitPubUnion(constraint)
+	if err != nil {
+		return nil, err
+	}
+	var carets []Interval
+	var union *Range
+	for _, alternative := range alternatives {
+		r, err := parsePubAlternative(alternative)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(strings.TrimSpace(alternative), "^") {
+			carets = append(carets, r.Intervals...)
+		}
+		if union == nil {
+			union = r
+		} else {
+			union = union.Union(r)
+		}
+	}
+
+	c := pubConstraintFromIntervals(union.Intervals)
+	for i := range c.Ranges {
+		for _, caret := range carets {
+			if c.Ranges[i].interval() == caret {
+				c.Ranges[i].Caret = true
+			}
+		}
+	}
+	return c, nil
+}
+
+// splitPubUnion splits a pub constraint into its union alternatives.
+func splitPubUnion(constraint string) ([]string, error) {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return nil, fmt.Errorf("empty pub constraint")
+	}
+	alternatives := pubUnionRegex.Split(constraint, -1)
+	for _, alternative := range alternatives {
+		if strings.TrimSpace(alternative) == "" {
+			return nil, fmt.Errorf("invalid pub union: %s", constraint)
+		}
+	}
+	return alternatives, nil
+}
+
+// pubConstraintFromIntervals converts non-empty intervals to pub ranges.
+func pubConstraintFromIntervals(intervals []Interval) *PubVersionConstraint {
+	c := &PubVersionConstraint{}
+	for _, interval := range intervals {
+		if interval.isEmptyCmp(comparePub) {
+			continue
+		}
+		c.Ranges = append(c.Ranges, PubVersionRange{
+			Min:        interval.Min,
+			Max:        interval.Max,
+			IncludeMin: interval.MinInclusive,
+			IncludeMax: interval.MaxInclusive,
+		})
+	}
+	return c
+}
+
+func (r PubVersionRange) interval() Interval {
+	return NewInterval(r.Min, r.Max, r.IncludeMin, r.IncludeMax)
+}
+
+// String returns the range as pub_semver's toString prints it: a version,
+// ^min, any, or comparators such as ">=1.0.0 <2.0.0". An exclusive upper
+// bound that still allows its prereleases is printed with a -∞ suffix.
+func (r PubVersionRange) String() string {
+	switch {
+	case r.Min == "" && r.Max == "":
+		return "any"
+	case r.Min != "" && r.Min == r.Max && r.IncludeMin && r.IncludeMax:
+		return r.Min
+	case r.Caret:
+		return "^" + r.Min
+	}
+
+	var b strings.Builder
+	if r.Min != "" {
+		if r.IncludeMin {
+			b.WriteString(">=")
+		} else {
+			b.WriteString(">")
+		}
+		b.WriteString(r.Min)
+	}
+	if r.Max == "" {
+		return b.String()
+	}
+	if r.Min != "" {
+		b.WriteByte(' ')
+	}
+	if r.IncludeMax {
+		b.WriteString("<=" + r.Max)
+		return b.String()
+	}
+	b.WriteByte('<')
+	maximum, _ := parseSemverValue(r.Max)
+	if maximum.pre == "0" && pubBuildIdentifier(r.Max) == "" {
+		// <2.0.0 parses as <2.0.0-0, so pub prints the shorter form.
+		b.WriteString(strings.Join(maximum.core[:], "."))
+		return b.String()
+	}
+	b.WriteString(r.Max)
+	minIsPrereleaseOfMax := false
+	if minimum, ok := parseSemverValue(r.Min); ok && r.Min != "" {
+		minIsPrereleaseOfMax = minimum.pre != "" && minimum.core == maximum.core
+	}
+	if maximum.pre == "" && pubBuildIdentifier(r.Max) == "" && !minIsPrereleaseOfMax {
+		b.WriteString("-∞")
+	}
+	return b.String()
+}
+
+// String returns the constraint as pub prints it, joining union ranges
+// with " or " and printing <empty> when nothing is allowed.
+func (c *PubVersionConstraint) String() string {
+	if len(c.Ranges) == 0 {
+		return pubEmptyConstraint
+	}
+	parts := make([]string, len(c.Ranges))
+	for i, r := range c.Ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, " or ")
+}
+
+// Range returns the constraint as a Range using the pub scheme.
+func (c *PubVersionConstraint) Range() *Range {
+	intervals := make([]Interval, len(c.Ranges))
+	for i, r := range c.Ranges {
+		intervals[i] = r.interval()
+	}
+	return rangeWithScheme(NewRange(intervals), schemePub)
+}
+
+// Allows reports whether version satisfies the constraint.
+func (c *PubVersionConstraint) Allows(version string) bool {
+	return c.Range().Contains(version)
+}
+
+// FormatPub returns a range in pub's constraint syntax, as pub_semver's
+// toString would print it. Pub has no != operator, so exclusions split
+// the ranges around them.
+func FormatPub(r *Range) string {
+	var intervals []Interval
+	for _, interval := range r.Intervals {
+		intervals = append(intervals, splitExcludedInterval(interval, r.Exclusions, comparePub)...)
+	}
+	return pubConstraintFromIntervals(intervals).String()
+}
+
+// PubSDKConstraint interprets a pubspec environment sdk constraint as pub
+// does when running on the Dart SDK sdkVersion. A missing constraint means
+// <2.0.0, and a range with no upper bound gets <2.0.0 when it allows any
+// version below it. On Dart 3 and later, a constraint that starts at 2.12
+// or above (null safe) and ends at <3.0.0 is widened to <4.0.0. Use
+// PubFlutterSDKConstraint for the flutter entry.
+func PubSDKConstraint(constraint, sdkVersion string) (*PubVersionConstraint, error) {
+	sdk, ok := parseSemverValue(sdkVersion)
+	if !ok || !validPubVersion(sdkVersion) {
+		return nil, fmt.Errorf("invalid dart sdk version: %s", sdkVersion)
+	}
+	if strings.TrimSpace(constraint) == "" {
+		constraint = "<2.0.0"
+	}
+	c, err := ParsePubVersionConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Ranges) != 1 {
+		return c, nil
+	}
+
+	r := &c.Ranges[0]
+	if r.Max == "" && (r.Min == "" || comparePub(r.Min, "2.0.0-0") < 0) {
+		r.Max, r.IncludeMax, r.Caret = "2.0.0-0", false, false
+	}
+	if cmpNumStr(sdk.core[0], "3") >= 0 && r.Max == "3.0.0-0" && !r.IncludeMax && pubNullSafe(r.Min) {
+		r.Max, r.Caret = "4.0.0-0", false
+	}
+	return c, nil
+}
+
+// PubFlutterSDKConstraint interprets a pubspec environment flutter
+// constraint as pub's SdkConstraint.interpretFlutterSdkConstraint does: a
+// single range keeps only its lower bound, since Flutter releases often
+// outgrow the upper bounds packages declare. A missing constraint allows
+// any Flutter version, and unions are used as written.
+func PubFlutterSDKConstraint(constraint string) (*PubVersionConstraint, error) {
+	if strings.TrimSpace(constraint) == "" {
+		constraint = "any"
+	}
+	c, err := ParsePubVersionConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Ranges) == 1 {
+		r := &c.Ranges[0]
+		r.Max, r.IncludeMax, r.Caret = "", false, false
+	}
+	return c, nil
+}
+
+// pubNullSafe reports whether a lower bound's language version is at least
+// 2.12, the first with null safety.
+func pubNullSafe(minimum string) bool {
+	parsed, ok := parseSemverValue(minimum)
+	if minimum == "" || !ok {
+		return false
+	}
+	if major := cmpNumStr(parsed.core[0], "2"); major != 0 {
+		return major > 0
+	}
+	return cmpNumStr(parsed.core[1], "12") >= 0
+}
@@ -0,0 +1,177 @@
+package vers
+
+import "testing"
+
+func TestParsePubVersionConstraintString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"any", "any"},
+		{"1.2.3", "1.2.3"},
+		{"^1.2.3", "^1.2.3"},
+		{"^0.2.3", "^0.2.3"},
+		{">=1.2.3 <2.0.0", ">=1.2.3 <2.0.0"},
+		{">=2.12.0<3.0.0", ">=2.12.0 <3.0.0"},
+		{">1.0.0 <=2.0.0", ">1.0.0 <=2.0.0"},
+		{">=1.0.0-beta <1.0.0", ">=1.0.0-beta <1.0.0"},
+		{"<2.0.0", "<2.0.0"},
+		{">=1.0.0", ">=1.0.0"},
+		{">=1.0.0 <=1.0.0", "1.0.0"},
+		{">=2.0.0 <1.0.0", "<empty>"},
+		{"<empty>", "<empty>"},
+		{"^1.0.0 || ^2.0.0", "^1.0.0 or ^2.0.0"},
+		{"^2.0.0 or ^1.0.0", "^1.0.0 or ^2.0.0"},
+		{"^1.0.0 or 1.5.0", "^1.0.0"},
+		{">=1.0.0 <1.5.0 or >=1.2.0 <2.0.0", ">=1.0.0 <2.0.0"},
+		// Prereleases of 2.0.0 separate these ranges, so they stay apart.
+		{">=1.0.0 <2.0.0 or >=2.0.0 <3.0.0", ">=1.0.0 <2.0.0 or >=2.0.0 <3.0.0"},
+		{">=1.0.0 || <2.0.0", "any"},
+		{"<empty> or 1.0.0", "1.0.0"},
+	}
+	for _, tt := range tests {
+		c, err := ParsePubVersionConstraint(tt.input)
+		if err != nil {
+			t.Errorf("ParsePubVersionConstraint(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := c.String(); got != tt.want {
+			t.Errorf("ParsePubVersionConstraint(%q).String() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParsePubVersionConstraintErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "^1.0.0 ||", "or ^1.0.0", "^1.0.0 | ^2.0.0", "^1.2", ">=1.0.0, <2.0.0", "!=1.0.0"} {
+		if c, err := ParsePubVersionConstraint(input); err == nil {
+			t.Errorf("ParsePubVersionConstraint(%q) = %v, expected error", input, c)
+		}
+	}
+}
+
+func TestPubVersionConstraintAllows(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParsePubVersionConstraint("^1.0.0 or ^3.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for version, want := range map[string]bool{
+		"1.0.0": true, "1.9.9": true, "2.0.0": false, "2.0.0-dev": false, "3.1.0": true, "4.0.0-0": false,
+	} {
+		if got := c.Allows(version); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", version, got, want)
+		}
+	}
+	r, err := ParseNative("^1.0.0 || ^3.0.0", "pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Contains("3.1.0") || r.Contains("2.5.0") {
+		t.Errorf("ParseNative union = %v", r)
+	}
+}
+
+func TestFormatPub(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		r    *Range
+		want string
+	}{
+		{Unbounded(), "any"},
+		{&Range{}, "<empty>"},
+		{NewRange([]Interval{NewInterval("1.0.0", "2.0.0-0", true, false)}), ">=1.0.0 <2.0.0"},
+		{NewRange([]Interval{NewInterval("1.0.0", "2.0.0", true, false)}), ">=1.0.0 <2.0.0-∞"},
+		{NewRange([]Interval{NewInterval("1.0.0", "2.0.0-beta", true, false)}), ">=1.0.0 <2.0.0-beta"},
+		{NewRange([]Interval{ExactInterval("1.2.3"), GreaterThanInterval("2.0.0", false)}), "1.2.3 or >2.0.0"},
+		{Unbounded().Exclude("1.5.0"), "<1.5.0-∞ or >1.5.0"},
+		{NewRange([]Interval{NewInterval("1.0.0", "2.0.0-0", true, false)}).Exclude("1.0.0"), ">1.0.0 <2.0.0"},
+	}
+	for _, tt := range tests {
+		if got := FormatPub(tt.r); got != tt.want {
+			t.Errorf("FormatPub(%v) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+
+	r, err := ParseNative(">=1.2.3 <2.0.0", "pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FormatPub(r); got != ">=1.2.3 <2.0.0" {
+		t.Errorf("FormatPub(ParseNative(>=1.2.3 <2.0.0)) = %q", got)
+	}
+}
+
+func TestPubSDKConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		sdk        string
+		want       string
+	}{
+		{"", "3.4.0", "<2.0.0"},
+		{"any", "3.4.0", "<2.0.0"},
+		{">=1.0.0", "3.4.0", ">=1.0.0 <2.0.0"},
+		{">=2.12.0", "3.4.0", ">=2.12.0"},
+		{">=2.12.0 <3.0.0", "3.4.0", ">=2.12.0 <4.0.0"},
+		{"^2.17.0", "3.0.0", ">=2.17.0 <4.0.0"},
+		{">=2.12.0 <3.0.0", "2.19.6", ">=2.12.0 <3.0.0"},
+		{">=2.10.0 <3.0.0", "3.4.0", ">=2.10.0 <3.0.0"},
+		{"^3.0.0", "3.4.0", "^3.0.0"},
+		{">=2.12.0 <=3.0.0", "3.4.0", ">=2.12.0 <=3.0.0"},
+		{">=2.12.0 <3.0.0", "3.5.0-180.3.beta", ">=2.12.0 <4.0.0"},
+	}
+	for _, tt := range tests {
+		c, err := PubSDKConstraint(tt.constraint, tt.sdk)
+		if err != nil {
+			t.Errorf("PubSDKConstraint(%q, %q) error: %v", tt.constraint, tt.sdk, err)
+			continue
+		}
+		if got := c.String(); got != tt.want {
+			t.Errorf("PubSDKConstraint(%q, %q) = %q, want %q", tt.constraint, tt.sdk, got, tt.want)
+		}
+	}
+
+	if _, err := PubSDKConstraint(">=2.12.0", "3.4"); err == nil {
+		t.Error("expected error for an invalid SDK version")
+	}
+}
+
+func TestPubFlutterSDKConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"", "any"},
+		{"any", "any"},
+		{">=3.10.0", ">=3.10.0"},
+		{">=3.10.0 <4.0.0", ">=3.10.0"},
+		{">3.10.0 <=3.19.0", ">3.10.0"},
+		{"^3.16.0", ">=3.16.0"},
+		{"<3.0.0", "any"},
+		{"3.16.0", ">=3.16.0"},
+		{"^1.0.0 || ^3.0.0", "^1.0.0 or ^3.0.0"},
+	}
+	for _, tt := range tests {
+		c, err := PubFlutterSDKConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("PubFlutterSDKConstraint(%q) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := c.String(); got != tt.want {
+			t.Errorf("PubFlutterSDKConstraint(%q) = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+
+	if _, err := PubFlutterSDKConstraint(">=3.10"); err == nil {
+		t.Error("expected error for an invalid constraint")
+	}
+}
@@ -2,12 +2,17 @@ package vers
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
 
 // Range represents a version range as a collection of intervals.
 // Multiple intervals represent a union (OR) of ranges.
+//
+// Range operations never modify their receiver or arguments, and the ranges
+// they return share no slices with them, so a Range can be reused and
+// combined from several goroutines as long as nothing writes to its fields.
 type Range struct {
 	Intervals  []Interval
 	Exclusions []string // Versions to exclude (from != constraints)
@@ -18,55 +23,113 @@ type Range struct {
 	Scheme string
 }
 
-// NewRange creates a new Range from intervals.
+// NewRange creates a new Range from a copy of intervals.
 func NewRange(intervals []Interval) *Range {
-	return &Range{Intervals: intervals}
+	return &Range{Intervals: slices.Clone(intervals)}
+}
+
+// Clone returns a copy of the range that shares no slices with it.
+func (r *Range) Clone() *Range {
+	if r == nil {
+		return nil
+	}
+	return &Range{
+		Intervals:      slices.Clone(r.Intervals),
+		Exclusions:     slices.Clone(r.Exclusions),
+		RawConstraints: slices.Clone(r.RawConstraints),
+		Scheme:         r.Scheme,
+	}
 }
 
 // Contains checks if the range contains the given version.
 func (r *Range) Contains(version string) bool {
+	scheme, cmp := r.containsScheme()
+	if !schemeAcceptsVersion(scheme, version) {
+		return false
+	}
+
+	// Check exclusions first
+	if _, _, excluded := excludedBy(r.Exclusions, version, scheme, cmp); excluded {
+		return false
+	}
+
+	// Check if version is in any interval
+	for _, interval := range r.Intervals {
+		if contains, _ := intervalContains(interval, version, scheme, cmp); contains {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsScheme returns the canonical scheme and comparison Contains uses.
+func (r *Range) containsScheme() (string, func(a, b string) int) {
 	scheme := canonicalScheme(r.Scheme)
 	cmp := compareFuncFor(r.Scheme)
 	if scheme == schemeCargo {
 		cmp = compareSemver
 	}
-	if scheme == schemeNPM || scheme == schemeCargo {
-		if _, err := ParseVersion(version); err != nil {
-			return false
-		}
+	return scheme, cmp
+}
+
+// schemeAcceptsVersion reports whether a scheme that rejects invalid
+// versions outright accepts version.
+func schemeAcceptsVersion(scheme, version string) bool {
+	switch scheme {
+	case schemeNPM, schemeCargo:
+		_, err := ParseVersion(version)
+		return err == nil
+	case schemeTerraform:
+		_, ok := parseTerraformVersion(version)
+		return ok
 	}
+	return true
+}
 
-	// Check exclusions first
-	for _, exc := range r.Exclusions {
+// excludedBy returns the exclusion that matches version and the scheme rule
+// used to match it.
+func excludedBy(exclusions []string, version, scheme string, cmp func(a, b string) int) (string, ExplanationRule, bool) {
+	for _, exc := range exclusions {
 		excluded := cmp(version, exc) == 0
+		var rule ExplanationRule
 		if scheme == schemePyPI {
 			excluded = pep440SpecifierEqual(version, exc)
+			rule = RulePEP440Equality
 		} else if scheme == schemeComposer && (isComposerBranchVersion(version) || isComposerBranchVersion(exc)) {
 			excluded = version == exc
+			rule = RuleComposerBranch
 		}
 		if excluded {
-			return false
+			return exc, rule, true
 		}
 	}
+	return "", "", false
+}
 
-	// Check if version is in any interval
-	for _, interval := range r.Intervals {
-		contains := interval.containsCmp(version, cmp)
-		switch scheme {
-		case schemePyPI:
-			contains = pypiIntervalContains(interval, version)
-		case schemeComposer:
-			contains = composerIntervalContains(interval, version)
-		}
-		if contains && (scheme == schemeNPM || scheme == schemeCargo) && !semverIntervalAllowsPrerelease(interval, version) {
-			contains = false
-		}
-		if contains {
-			return true
+// intervalContains checks one interval under the scheme's rules and returns
+// the scheme rule that decided the result, if any.
+func intervalContains(interval Interval, version, scheme string, cmp func(a, b string) int) (bool, ExplanationRule) {
+	switch scheme {
+	case schemePyPI:
+		return pypiIntervalContains(interval, version)
+	case schemeComposer:
+		contains := composerIntervalContains(interval, version)
+		if isComposerBranchVersion(version) || isComposerBranchVersion(interval.Min) || isComposerBranchVersion(interval.Max) {
+			return contains, RuleComposerBranch
 		}
+		return contains, ""
 	}
-
-	return false
+	if !interval.containsCmp(version, cmp) {
+		return false, ""
+	}
+	if (scheme == schemeNPM || scheme == schemeCargo) && !semverIntervalAllowsPrerelease(interval, version) {
+		return false, RuleSemverPrerelease
+	}
+	if scheme == schemeTerraform && !terraformIntervalAllowsPrerelease(interval, version) {
+		return false, RuleTerraformPrerelease
+	}
+	return true, ""
 }
 
 func composerIntervalContains(interval Interval, version string) bool {
@@ -101,23 +164,25 @@ func semverIntervalAllowsPrerelease(interval Interval, version string) bool {
 	return false
 }
 
-func pypiIntervalContains(interval Interval, version string) bool {
+// pypiIntervalContains applies the PEP 440 rules that plain bound comparison
+// misses and returns the rule that decided the result, if any.
+func pypiIntervalContains(interval Interval, version string) (bool, ExplanationRule) {
 	if interval.Min != "" && interval.Max != "" && interval.MinInclusive && interval.MaxInclusive &&
 		comparePyPI(interval.Min, interval.Max) == 0 {
-		return pep440SpecifierEqual(version, interval.Min)
+		return pep440SpecifierEqual(version, interval.Min), RulePEP440Equality
 	}
 	if !interval.containsCmp(version, comparePyPI) {
-		return false
+		return false, ""
 	}
 	candidate, candidateOK := parsePEP440(version)
 	if !candidateOK {
-		return false
+		return false, RuleInvalidVersion
 	}
 	if interval.Min != "" && !interval.MinInclusive {
 		bound, boundOK := parsePEP440(interval.Min)
 		if boundOK {
 			if pep440SpecifierEqual(version, interval.Min) {
-				return false
+				return false, RulePEP440Equality
 			}
 			withoutPost := candidate
 			withoutPost.hasPost = false
@@ -126,7 +191,7 @@ func pypiIntervalContains(interval Interval, version string) bool {
 			withoutPost.dev = ""
 			withoutPost.local = nil
 			if candidate.hasPost && pep440VersionsEqual(withoutPost, bound, true) {
-				return false
+				return false, RulePEP440PostRelease
 			}
 		}
 	}
@@ -135,18 +200,18 @@ func pypiIntervalContains(interval Interval, version string) bool {
 		if boundOK {
 			if !bound.hasPre && !bound.hasPost && !bound.hasDev &&
 				samePEP440Release(candidate, bound) && (candidate.hasPre || candidate.hasDev) {
-				return false
+				return false, RulePEP440PreRelease
 			}
 			withoutDev := candidate
 			withoutDev.hasDev = false
 			withoutDev.dev = ""
 			withoutDev.local = nil
 			if candidate.hasDev && pep440VersionsEqual(withoutDev, bound, true) {
-				return false
+				return false, RulePEP440PreRelease
 			}
 		}
 	}
-	return true
+	return true, ""
 }
 
 func pep440SpecifierEqual(version, specifier string) bool {
@@ -268,10 +333,10 @@ func (r *Range) MinimumVersion() (string, bool) {
 // Union returns a new Range that is the union of this range and another.
 func (r *Range) Union(other *Range) *Range {
 	if r.IsEmpty() {
-		return other
+		return other.Clone()
 	}
 	if other.IsEmpty() {
-		return r
+		return r.Clone()
 	}
 
 	// Combine all intervals
@@ -411,7 +476,7 @@ func (r *Range) Exclude(version string) *Range {
 	exclusions = append(exclusions, version)
 
 	return &Range{
-		Intervals:  r.Intervals,
+		Intervals:  slices.Clone(r.Intervals),
 		Exclusions: exclusions,
 		Scheme:     r.Scheme,
 	}
@@ -1,6 +1,10 @@
 package vers
 
-import "testing"
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
 
 func TestRangeContains(t *testing.T) {
 	tests := []struct {
@@ -469,3 +473,109 @@ func TestUnbounded(t *testing.T) {
 		t.Error("IsUnbounded() should return true")
 	}
 }
+
+func TestRangeOperationsDoNotAlias(t *testing.T) {
+	t.Parallel()
+
+	base, err := ParseNative(">=1.0.0 <2.0.0 || >=3.0.0", "npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := base.Clone()
+
+	results := []*Range{
+		base.Union(Empty()),
+		Empty().Union(base),
+		base.Union(base),
+		base.Intersect(Unbounded()),
+		base.Exclude("1.5.0"),
+		base.Clone(),
+	}
+	for i, result := range results {
+		if result == base {
+			t.Fatalf("result %d is the receiver itself", i)
+		}
+		for j := range result.Intervals {
+			result.Intervals[j].Min = "9.9.9"
+		}
+		for j := range result.RawConstraints {
+			result.RawConstraints[j].Max = "9.9.9"
+		}
+		if len(result.Exclusions) > 0 {
+			result.Exclusions[0] = "9.9.9"
+		}
+	}
+	if !reflect.DeepEqual(base, snapshot) {
+		t.Errorf("modifying results changed the source range: %+v, want %+v", base, snapshot)
+	}
+
+	intervals := []Interval{NewInterval("1.0.0", "2.0.0", true, false)}
+	r := NewRange(intervals)
+	intervals[0].Min = "0.0.0"
+	if r.Intervals[0].Min != "1.0.0" {
+		t.Errorf("NewRange shares the caller's slice: %+v", r.Intervals)
+	}
+}
+
+func TestPubUpperBoundAdjustmentCopies(t *testing.T) {
+	t.Parallel()
+
+	r := &Range{
+		Intervals:      []Interval{NewInterval("1.0.0", "2.0.0", true, false)},
+		RawConstraints: []Interval{NewInterval("1.0.0", "2.0.0", true, false)},
+		Scheme:         "pub",
+	}
+	adjusted := adjustPubExclusiveUpperBounds(r)
+	if adjusted.Intervals[0].Max != "2.0.0-0" {
+		t.Errorf("adjusted upper bound = %q, want 2.0.0-0", adjusted.Intervals[0].Max)
+	}
+	if r.Intervals[0].Max != "2.0.0" || r.RawConstraints[0].Max != "2.0.0" {
+		t.Errorf("adjustment modified its input: %+v", r)
+	}
+}
+
+// TestRangeConcurrentCombine combines shared ranges from many goroutines;
+// run it with -race to catch writes to shared slices.
+func TestRangeConcurrentCombine(t *testing.T) {
+	t.Parallel()
+
+	shared := []*Range{}
+	for _, constraint := range []string{"^1.2.0", ">=2.0.0 <2.5.0", "~3.1.0", "1.0.0 || >=4.0.0"} {
+		r, err := ParseNative(constraint, "npm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		shared = append(shared, r.Exclude("1.2.5"))
+	}
+	pub, err := ParseNative(">=1.0.0 <2.0.0", "pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots := make([]*Range, len(shared))
+	for i, r := range shared {
+		snapshots[i] = r.Clone()
+	}
+
+	var wg sync.WaitGroup
+	for g := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				a := shared[(g+i)%len(shared)]
+				b := shared[(g+i+1)%len(shared)]
+				combined := a.Union(b).Intersect(a).Exclude("1.3.0").Union(Empty())
+				combined.Contains("1.3.0")
+				_ = ToVersString(combined, "npm")
+				_ = pub.Intersect(pub).Union(pub).String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, r := range shared {
+		if !reflect.DeepEqual(r, snapshots[i]) {
+			t.Errorf("shared range %d changed: %+v, want %+v", i, r, snapshots[i])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequiresPython reports whether a Python version satisfies a Requires-Python
+// specifier set, such as ">=3.8,!=3.9.*", the way pip checks it. pip compares
+// only the interpreter's major, minor and micro numbers, so 3.8.0rc1 is
+// treated as 3.8.0 and satisfies >=3.8. Missing numbers count as zero, and
+// an empty specifier set accepts every version.
+func RequiresPython(specifier, pythonVersion string) (bool, error) {
+	release, err := pythonRelease(pythonVersion)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(specifier) == "" {
+		return true, nil
+	}
+	r, err := ParseNative(specifier, schemePyPI)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(release), nil
+}
+
+// pythonRelease returns the major.minor.micro release of a Python version.
+// The trailing + that untagged interpreter builds report is ignored.
+func pythonRelease(version string) (string, error) {
+	parsed, ok := parsePEP440(strings.TrimSuffix(strings.TrimSpace(version), "+"))
+	if !ok || cmpNumStr(parsed.epoch, "0") != 0 {
+		return "", fmt.Errorf("invalid python version: %s", version)
+	}
+	release := make([]string, 3) //nolint:mnd
+	for i := range release {
+		release[i] = releasePart(parsed.release, i)
+	}
+	return strings.Join(release, "."), nil
+}
+
+// RequiresPythonIntersection returns the Python versions that satisfy every
+// Requires-Python specifier set, such as those of all the packages in an
+// environment. Empty specifiers place no restriction, so with none the result
+// is unbounded. An empty result means no Python version suits every package.
+func RequiresPythonIntersection(specifiers ...string) (*Range, error) {
+	var result *Range
+	for _, specifier := range specifiers {
+		if strings.TrimSpace(specifier) == "" {
+			continue
+		}
+		r, err := ParseNative(specifier, schemePyPI)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = r
+		} else {
+			result = result.Intersect(r)
+		}
+	}
+	if result == nil {
+		result = Unbounded()
+		result.Scheme = schemePyPI
+	}
+	return result, nil
+}
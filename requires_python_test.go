@@ -0,0 +1,82 @@
+package vers
+
+import "testing"
+
+func TestRequiresPython(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		specifier string
+		python    string
+		want      bool
+	}{
+		{">=3.8", "3.8.0rc1", true},
+		{">=3.8", "3.8", true},
+		{">=3.8", "3.7.17", false},
+		{">=3.10", "3.9.18", false},
+		{">=3.1", "3.10.2", true},
+		{"<3.10", "3.10.0a7", false},
+		{">=3.8,!=3.9.*", "3.9.1", false},
+		{">=3.8,!=3.9.*", "3.11.4", true},
+		{"~=3.7", "3.12.1", true},
+		{"==3.11.*", "3.11.0b4", true},
+		{">3.12", "3.12.1", true},
+		{"", "2.7.18", true},
+		{" >= 3.6 , < 4 ", "3.13.0+", true},
+	}
+	for _, tt := range tests {
+		got, err := RequiresPython(tt.specifier, tt.python)
+		if err != nil {
+			t.Errorf("RequiresPython(%q, %q) error: %v", tt.specifier, tt.python, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RequiresPython(%q, %q) = %v, want %v", tt.specifier, tt.python, got, tt.want)
+		}
+	}
+
+	if _, err := RequiresPython(">=3.8", "not a version"); err == nil {
+		t.Error("RequiresPython with an invalid Python version expected error")
+	}
+	if _, err := RequiresPython(">=3.8", "1!3.8"); err == nil {
+		t.Error("RequiresPython with an epoch expected error")
+	}
+}
+
+func TestRequiresPythonIntersection(t *testing.T) {
+	t.Parallel()
+
+	r, err := RequiresPythonIntersection(">=3.8", "", "<3.13,!=3.9.*", ">=3.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for version, want := range map[string]bool{
+		"3.7.9":  false,
+		"3.8.0":  true,
+		"3.9.5":  false,
+		"3.10.0": true,
+		"3.12.9": true,
+		"3.13.0": false,
+	} {
+		if got := r.Contains(version); got != want {
+			t.Errorf("intersection Contains(%q) = %v, want %v", version, got, want)
+		}
+	}
+
+	r, err = RequiresPythonIntersection(">=3.12", "<3.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.IsEmpty() {
+		t.Errorf("disjoint intersection = %v, want empty", r)
+	}
+
+	r, err = RequiresPythonIntersection()
+	if err != nil || !r.IsUnbounded() || r.Scheme != "pypi" {
+		t.Errorf("RequiresPythonIntersection() = %+v, %v", r, err)
+	}
+
+	if _, err := RequiresPythonIntersection(">=3.8", "===3.8"); err == nil {
+		t.Error("RequiresPythonIntersection with an unsupported specifier expected error")
+	}
+}
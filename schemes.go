@@ -88,6 +88,8 @@ func compareGo(a, b string) int {
 	if !strings.HasPrefix(a, "v") && !strings.HasPrefix(b, "v") {
 		return compareSemver(a, b)
 	}
+	// A bare version compared with a v-prefixed one stands for its v form.
+	a, b = goCanonicalVersion(a), goCanonicalVersion(b)
 	left, leftOK := parseGoVersion(a)
 	right, rightOK := parseGoVersion(b)
 	if !leftOK || !rightOK {
@@ -108,6 +110,19 @@ func compareGo(a, b string) int {
 	return compareSemverPrereleaseStrings(left.pre, right.pre)
 }
 
+// goCanonicalVersion returns version with the v prefix the go command
+// requires, such as v1.2.3 for 1.2.3. Versions that do not parse either way
+// are returned unchanged.
+func goCanonicalVersion(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	if _, ok := parseGoVersion("v" + version); ok {
+		return "v" + version
+	}
+	return version
+}
+
 func parseGoVersion(version string) (semverValue, bool) {
 	if len(version) < 2 || version[0] != 'v' {
 		return semverValue{}, false
@@ -148,7 +163,10 @@ func parseGoVersion(version string) (semverValue, bool) {
 		}
 		remainder = ""
 	}
-	return parsed, remainder == ""
+	if remainder != "" || !validGoPseudoTimestamp("v"+version) {
+		return semverValue{}, false
+	}
+	return parsed, true
 }
 
 func validGoCore(parts []string) bool {
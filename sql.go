@@ -0,0 +1,296 @@
+package vers
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Scan reads a vers URI from a text column.
+func (r *Range) Scan(src any) error {
+	text, err := scanText(src, "Range")
+	if err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(text))
+}
+
+// Value stores the range as its vers URI. It has a value receiver, like
+// MarshalJSON, so that ranges held by value can be passed as query
+// arguments; database/sql stores a nil *Range as NULL.
+func (r Range) Value() (driver.Value, error) {
+	text, err := r.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// VersionValue is a version together with the scheme it compares under.
+// It is stored as the plain version text; set Scheme before scanning.
+type VersionValue struct {
+	Version string
+	Scheme  string
+}
+
+// Scan reads the version from a text column, keeping the receiver's scheme.
+func (v *VersionValue) Scan(src any) error {
+	text, err := scanText(src, "VersionValue")
+	if err != nil {
+		return err
+	}
+	v.Version = text
+	return nil
+}
+
+// Value stores the version text.
+func (v VersionValue) Value() (driver.Value, error) {
+	return v.Version, nil
+}
+
+// SortKey returns the version's sort key under its scheme.
+func (v VersionValue) SortKey() (string, error) {
+	return SortKey(v.Version, v.Scheme)
+}
+
+// Compare compares two versions under the receiver's scheme.
+func (v VersionValue) Compare(other VersionValue) int {
+	return CompareWithScheme(v.Version, other.Version, v.Scheme)
+}
+
+func scanText(src any, target string) (string, error) {
+	switch value := src.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case nil:
+		return "", fmt.Errorf("cannot scan NULL into %s", target)
+	}
+	return "", fmt.Errorf("cannot scan %T into %s", src, target)
+}
+
+// SortKey returns a string whose byte order matches the version order of
+// scheme, so a database can compare versions by comparing keys. Versions
+// that compare equal, such as 1.0 and 1.0.0 in pypi, share a key. Keys are
+// ASCII and must be compared bytewise, for example in a Postgres column
+// declared COLLATE "C".
+//
+// Supported schemes are npm, semver, cargo, go, hex and pypi.
+func SortKey(version, scheme string) (string, error) {
+	scheme = canonicalScheme(scheme)
+	if !validVersionForScheme(version, scheme) {
+		return "", fmt.Errorf("invalid %s version: %s", scheme, version)
+	}
+	switch scheme {
+	case schemeNPM, schemeSemVer, schemeCargo, schemeGo, schemeHex:
+		return semverSortKey(version)
+	case schemePyPI:
+		return pep440SortKey(version)
+	}
+	return "", fmt.Errorf("sort keys are not supported for %s versions", scheme)
+}
+
+// sortKeyTerminator ends a variable-length part of a key. It sorts below
+// every character a version part can contain.
+const sortKeyTerminator = '!'
+
+// writeSortKeyNumber writes a number as its two-digit length followed by
+// its digits, so longer numbers sort after shorter ones.
+func writeSortKeyNumber(key *strings.Builder, number string) error {
+	number = trimLeadingZeros(number)
+	if len(number) > 99 { //nolint:mnd
+		return fmt.Errorf("number too long for a sort key: %s", number)
+	}
+	fmt.Fprintf(key, "%02d%s", len(number), number)
+	return nil
+}
+
+// semverSortKey encodes major, minor and patch, then ~ for a release or -
+// and the identifiers for a prerelease. Numeric identifiers sort before
+// alphanumeric ones, and a shorter list of identifiers sorts first.
+func semverSortKey(version string) (string, error) {
+	m := SemanticVersionRegex.FindStringSubmatch(version)
+	var key strings.Builder
+	for _, number := range m[1:4] {
+		if err := writeSortKeyNumber(&key, number); err != nil {
+			return "", err
+		}
+	}
+	if m[4] == "" {
+		key.WriteByte('~')
+		return key.String(), nil
+	}
+	key.WriteByte('-')
+	for _, identifier := range strings.Split(m[4], ".") {
+		if isDigits(identifier) {
+			key.WriteByte('0')
+			if err := writeSortKeyNumber(&key, identifier); err != nil {
+				return "", err
+			}
+			continue
+		}
+		key.WriteByte('1')
+		key.WriteString(identifier)
+		key.WriteByte(sortKeyTerminator)
+	}
+	return key.String(), nil
+}
+
+// pep440SortKey follows the ordering of packaging's Version: epoch, release
+// without trailing zeros, then pre, post and dev releases, then the local
+// version.
+func pep440SortKey(version string) (string, error) {
+	v, _ := parsePEP440(version)
+	var key strings.Builder
+	if err := writeSortKeyNumber(&key, v.epoch); err != nil {
+		return "", err
+	}
+
+	release := v.release
+	for len(release) > 1 && trimLeadingZeros(release[len(release)-1]) == "0" {
+		release = release[:len(release)-1]
+	}
+	for _, part := range release {
+		if err := writeSortKeyNumber(&key, part); err != nil {
+			return "", err
+		}
+	}
+	key.WriteByte(sortKeyTerminator)
+
+	switch {
+	case v.hasPre:
+		fmt.Fprintf(&key, "1%d", v.preTag)
+		if err := writeSortKeyNumber(&key, v.preNum); err != nil {
+			return "", err
+		}
+	case v.hasDev && !v.hasPost:
+		// A dev release of a final release sorts before its pre-releases.
+		key.WriteByte('0')
+	default:
+		key.WriteByte('2')
+	}
+	if v.hasPost {
+		key.WriteByte('1')
+		if err := writeSortKeyNumber(&key, v.post); err != nil {
+			return "", err
+		}
+	} else {
+		key.WriteByte('0')
+	}
+	if v.hasDev {
+		key.WriteByte('0')
+		if err := writeSortKeyNumber(&key, v.dev); err != nil {
+			return "", err
+		}
+	} else {
+		key.WriteByte('1')
+	}
+
+	if len(v.local) > 0 {
+		key.WriteByte('+')
+		for _, part := range v.local {
+			if part.isNum {
+				key.WriteByte('1')
+				if err := writeSortKeyNumber(&key, part.s); err != nil {
+					return "", err
+				}
+				continue
+			}
+			key.WriteByte('0')
+			key.WriteString(strings.ToLower(part.s))
+			key.WriteByte(sortKeyTerminator)
+		}
+	}
+	return key.String(), nil
+}
+
+// SQLPredicate renders the range as a SQL condition over column, which must
+// hold SortKey values for the range's scheme. Bounds are passed as
+// Postgres-style $1, $2, ... arguments. column is inserted as written, so it
+// must be a trusted identifier.
+//
+// The condition compares bounds only. Scheme rules that Contains applies on
+// top of the bounds, such as npm excluding prereleases from ^1.0.0, are not
+// expressed, so check matching rows with Contains where they matter.
+func (r *Range) SQLPredicate(column string) (string, []any, error) {
+	var args []any
+	placeholder := func(version string) (string, error) {
+		key, err := SortKey(version, r.Scheme)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, key)
+		return fmt.Sprintf("$%d", len(args)), nil
+	}
+
+	cmp := compareFuncFor(r.Scheme)
+	var alternatives []string
+	for _, interval := range r.Intervals {
+		if interval.isEmptyCmp(cmp) {
+			continue
+		}
+		if interval.IsUnbounded() {
+			alternatives = []string{"TRUE"}
+			args = nil
+			break
+		}
+		condition, err := intervalSQLCondition(interval, column, placeholder)
+		if err != nil {
+			return "", nil, err
+		}
+		alternatives = append(alternatives, condition)
+	}
+	if len(alternatives) == 0 {
+		return "FALSE", nil, nil
+	}
+
+	predicate := strings.Join(alternatives, " OR ")
+	if len(r.Exclusions) > 0 {
+		excluded := make([]string, len(r.Exclusions))
+		for i, exclusion := range r.Exclusions {
+			p, err := placeholder(exclusion)
+			if err != nil {
+				return "", nil, err
+			}
+			excluded[i] = p
+		}
+		predicate = fmt.Sprintf("(%s) AND %s NOT IN (%s)", predicate, column, strings.Join(excluded, ", "))
+	}
+	return predicate, args, nil
+}
+
+func intervalSQLCondition(interval Interval, column string, placeholder func(string) (string, error)) (string, error) {
+	if interval.Min != "" && interval.Min == interval.Max && interval.MinInclusive && interval.MaxInclusive {
+		p, err := placeholder(interval.Min)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s = %s", column, p), nil
+	}
+
+	var conditions []string
+	if interval.Min != "" {
+		p, err := placeholder(interval.Min)
+		if err != nil {
+			return "", err
+		}
+		operator := ">"
+		if interval.MinInclusive {
+			operator = ">="
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", column, operator, p))
+	}
+	if interval.Max != "" {
+		p, err := placeholder(interval.Max)
+		if err != nil {
+			return "", err
+		}
+		operator := "<"
+		if interval.MaxInclusive {
+			operator = "<="
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", column, operator, p))
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", nil
+}
@@ -0,0 +1,215 @@
+package vers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*Range)(nil)
+	_ driver.Valuer = Range{}
+	_ sql.Scanner   = (*VersionValue)(nil)
+	_ driver.Valuer = VersionValue{}
+)
+
+func TestRangeScanValue(t *testing.T) {
+	t.Parallel()
+
+	uri := "vers:npm/>=1.0.0|<2.0.0"
+	for _, src := range []any{uri, []byte(uri)} {
+		var r Range
+		if err := r.Scan(src); err != nil {
+			t.Fatalf("Scan(%T) error: %v", src, err)
+		}
+		value, err := r.Value()
+		if err != nil {
+			t.Fatalf("Value() error: %v", err)
+		}
+		if value != uri {
+			t.Errorf("Value() = %v, want %q", value, uri)
+		}
+	}
+
+	var r Range
+	for _, src := range []any{nil, 42, "not a vers"} {
+		if err := r.Scan(src); err == nil {
+			t.Errorf("Scan(%v) expected error", src)
+		}
+	}
+
+	var null *Range
+	if value, err := driver.DefaultParameterConverter.ConvertValue(null); value != nil || err != nil {
+		t.Errorf("ConvertValue(nil *Range) = %v, %v, want nil, nil", value, err)
+	}
+	held, _ := Parse(uri)
+	if value, err := driver.DefaultParameterConverter.ConvertValue(*held); value != uri || err != nil {
+		t.Errorf("ConvertValue(Range) = %v, %v, want %q", value, err, uri)
+	}
+	if _, err := Exact("1.0").Value(); err == nil {
+		t.Error("Value() without a scheme expected error")
+	}
+}
+
+func TestVersionValueScan(t *testing.T) {
+	t.Parallel()
+
+	v := VersionValue{Scheme: "pypi"}
+	if err := v.Scan([]byte("1.0rc1")); err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+	want := VersionValue{Version: "1.0rc1", Scheme: "pypi"}
+	if v != want {
+		t.Errorf("Scan = %+v, want %+v", v, want)
+	}
+	if value, _ := v.Value(); value != "1.0rc1" {
+		t.Errorf("Value() = %v, want 1.0rc1", value)
+	}
+	if v.Compare(VersionValue{Version: "1.0"}) >= 0 {
+		t.Error("1.0rc1 should sort before 1.0 in pypi")
+	}
+	if err := v.Scan(nil); err == nil {
+		t.Error("Scan(nil) expected error")
+	}
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scheme   string
+		versions []string
+	}{
+		{"npm", []string{
+			"0.0.1", "0.9.0", "1.0.0-0", "1.0.0-1", "1.0.0-2", "1.0.0-10", "1.0.0-alpha",
+			"1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-alpha-b", "1.0.0-beta", "1.0.0-beta.2",
+			"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0", "10.0.0",
+			"123456789012.0.0",
+		}},
+		{"semver", []string{"1.0.0-a", "1.0.0-a.0", "1.0.0-a0", "1.0.0-aa", "1.0.0+build", "1.0.1"}},
+		{"cargo", []string{"0.1.0", "0.1.1-pre", "0.1.1", "1.0.0"}},
+		{"go", []string{
+			"v0.0.0-20190101000000-abcdefabcdef", "v0.1.0", "v1.0.0-rc.1", "v1.0.0",
+			"v2.0.0+incompatible", "v2.1.0+incompatible",
+		}},
+		{"pypi", []string{
+			"1.0.dev0", "1.0.dev1", "1.0a1.dev1", "1.0a1", "1.0a1.post1.dev1", "1.0a1.post1",
+			"1.0a2", "1.0b1", "1.0rc1", "1.0rc1.post1", "1.0", "1.0+abc", "1.0+abc.2", "1.0+1",
+			"1.0+2", "1.0+10", "1.0.post1.dev1", "1.0.post1", "1.0.post2", "1.0.1", "1.1.dev1",
+			"1.1", "2.0", "10.0", "1!0.1",
+		}},
+	}
+
+	for _, tt := range tests {
+		keys := make([]string, len(tt.versions))
+		for i, v := range tt.versions {
+			key, err := SortKey(v, tt.scheme)
+			if err != nil {
+				t.Fatalf("SortKey(%q, %s) error: %v", v, tt.scheme, err)
+			}
+			keys[i] = key
+		}
+		for i := range tt.versions {
+			for j := range tt.versions {
+				want := CompareWithScheme(tt.versions[i], tt.versions[j], tt.scheme)
+				got := cmpString(keys[i], keys[j])
+				if got != want {
+					t.Errorf("%s: keys of %q and %q compare %d, versions compare %d",
+						tt.scheme, tt.versions[i], tt.versions[j], got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSortKeyEquivalentVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scheme string
+		a, b   string
+	}{
+		{"pypi", "1.0", "1.0.0"},
+		{"pypi", "1.0-rc.1", "1.0rc1"},
+		{"pypi", "01.2", "1.2"},
+		{"npm", "1.0.0+a", "1.0.0+b"},
+		{"golang", "v1.0.0", "1.0.0"},
+	}
+	for _, tt := range tests {
+		a, errA := SortKey(tt.a, tt.scheme)
+		b, errB := SortKey(tt.b, tt.scheme)
+		if errA != nil || errB != nil {
+			t.Fatalf("SortKey errors: %v, %v", errA, errB)
+		}
+		if a != b {
+			t.Errorf("SortKey(%q) = %q, SortKey(%q) = %q, want equal", tt.a, a, tt.b, b)
+		}
+	}
+
+	if _, err := SortKey("1.0", "maven"); err == nil {
+		t.Error("SortKey for maven expected error")
+	}
+	if _, err := SortKey("not a version", "npm"); err == nil {
+		t.Error("SortKey for an invalid version expected error")
+	}
+}
+
+func TestSQLPredicate(t *testing.T) {
+	t.Parallel()
+
+	key := func(v, scheme string) any {
+		k, err := SortKey(v, scheme)
+		if err != nil {
+			t.Fatalf("SortKey(%q) error: %v", v, err)
+		}
+		return k
+	}
+
+	tests := []struct {
+		uri  string
+		want string
+		args []any
+	}{
+		{
+			"vers:npm/>=1.0.0|<2.0.0",
+			"(sort_key >= $1 AND sort_key < $2)",
+			[]any{key("1.0.0", "npm"), key("2.0.0", "npm")},
+		},
+		{
+			"vers:pypi/>=1.0|!=1.5|<2.0",
+			"((sort_key >= $1 AND sort_key < $2)) AND sort_key NOT IN ($3)",
+			[]any{key("1.0", "pypi"), key("2.0", "pypi"), key("1.5", "pypi")},
+		},
+		{
+			"vers:npm/1.0.0|>2.0.0",
+			"sort_key = $1 OR (sort_key > $2)",
+			[]any{key("1.0.0", "npm"), key("2.0.0", "npm")},
+		},
+		{"vers:npm/*", "TRUE", nil},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.uri)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.uri, err)
+		}
+		got, args, err := r.SQLPredicate("sort_key")
+		if err != nil {
+			t.Fatalf("SQLPredicate(%q) error: %v", tt.uri, err)
+		}
+		if got != tt.want {
+			t.Errorf("SQLPredicate(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("SQLPredicate(%q) args = %v, want %v", tt.uri, args, tt.args)
+		}
+	}
+
+	if got, args, err := Empty().SQLPredicate("sort_key"); got != "FALSE" || args != nil || err != nil {
+		t.Errorf("empty SQLPredicate = %q, %v, %v", got, args, err)
+	}
+	maven, _ := Parse("vers:maven/>=1.0")
+	if _, _, err := maven.SQLPredicate("sort_key"); err == nil {
+		t.Error("SQLPredicate for maven expected error")
+	}
+}
@@ -0,0 +1,70 @@
+package vers
+
+import "strings"
+
+// mavenPrereleaseQualifiers are the Maven and Gradle qualifiers that mark a
+// version as not yet released.
+var mavenPrereleaseQualifiers = []string{"alpha", "beta", "milestone", "rc", "cr", "snapshot", "preview", "dev", "ea"}
+
+// isPrereleaseForScheme reports whether a version is a prerelease under the
+// scheme's own notion of one: a semver prerelease tag, a PEP 440 pre or dev
+// release, a Composer stability below stable, a Debian tilde and so on.
+func isPrereleaseForScheme(version, scheme string) bool {
+	switch canonicalScheme(scheme) {
+	case schemePyPI:
+		if parsed, ok := parsePEP440(version); ok {
+			return parsed.hasPre || parsed.hasDev
+		}
+	case schemeComposer:
+		if isComposerBranchVersion(version) {
+			return true
+		}
+		if parsed, ok := parseComposerVersion(version); ok {
+			return parsed.stability < composerStabilityStable
+		}
+	case schemeGo:
+		if parsed, ok := parseGoVersion(version); ok {
+			return parsed.pre != ""
+		}
+	case schemeConda:
+		return isCondaPrerelease(version)
+	case schemeTerraform:
+		if parsed, ok := parseTerraformVersion(version); ok {
+			return parsed.prerelease != ""
+		}
+	case schemeNuGet:
+		release, _, _ := strings.Cut(version, "+")
+		return strings.Contains(release, "-")
+	case schemeGem:
+		return strings.IndexFunc(version, func(r rune) bool { return r < 128 && isLetter(byte(r)) }) >= 0
+	case schemeDeb, schemeRPM:
+		return strings.Contains(version, "~")
+	case schemeMaven, schemeGradle:
+		return hasMavenPrereleaseQualifier(version)
+	case schemeHackage:
+		return false
+	}
+	parsed, err := ParseVersion(version)
+	return err == nil && parsed.IsPrerelease()
+}
+
+// hasMavenPrereleaseQualifier reports whether any qualifier of a Maven
+// version names a prerelease, including the a1, b2 and m3 shorthands.
+func hasMavenPrereleaseQualifier(version string) bool {
+	tokens := strings.FieldsFunc(strings.ToLower(version), func(r rune) bool { return r == '.' || r == '-' || r == '_' })
+	for _, token := range tokens {
+		qualifier := strings.TrimRight(token, "0123456789")
+		if qualifier == "" {
+			continue
+		}
+		if len(qualifier) < len(token) && (qualifier == "a" || qualifier == "b" || qualifier == "m") {
+			return true
+		}
+		for _, prerelease := range mavenPrereleaseQualifiers {
+			if qualifier == prerelease || strings.HasSuffix(qualifier, prerelease) {
+				return true
+			}
+		}
+	}
+	return false
+}
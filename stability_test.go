@@ -0,0 +1,46 @@
+package vers
+
+import "testing"
+
+func TestIsPrereleaseForScheme(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version, scheme string
+		want            bool
+	}{
+		{"1.0.0", "npm", false},
+		{"1.0.0-rc.1", "npm", true},
+		{"1.0rc1", "pypi", true},
+		{"1.0.dev3", "pypi", true},
+		{"1.0.post1", "pypi", false},
+		{"1.0.0-beta2", "composer", true},
+		{"1.0.0-p1", "composer", false},
+		{"dev-main", "composer", true},
+		{"v1.2.3-pre", "go", true},
+		{"v1.2.3", "go", false},
+		{"1.0.0-beta", "nuget", true},
+		{"1.0.0+build-1", "nuget", false},
+		{"1.0.0.pre", "gem", true},
+		{"1.0.0", "rubygems", false},
+		{"1.0~rc1-1", "deb", true},
+		{"1.0-1", "deb", false},
+		{"1.0-SNAPSHOT", "maven", true},
+		{"1.0-M1", "maven", true},
+		{"1.0.Final", "maven", false},
+		{"1.0-sp1", "maven", false},
+		{"1.0.0-alpha", "terraform", true},
+		{"1.2.3", "hackage", false},
+		{"1.0a1", "conda", true},
+		{"1.0.dev0", "conda", true},
+		{"1.0rc1", "conda", true},
+		{"1.0.post1", "conda", false},
+		{"1.0.0+local", "conda", false},
+		{"1!2.0", "conda", false},
+	}
+	for _, tt := range tests {
+		if got := isPrereleaseForScheme(tt.version, tt.scheme); got != tt.want {
+			t.Errorf("isPrereleaseForScheme(%q, %s) = %v, want %v", tt.version, tt.scheme, got, tt.want)
+		}
+	}
+}
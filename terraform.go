@@ -0,0 +1,283 @@
+package vers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Terraform and OpenTofu version constraints follow HashiCorp's go-version.
+// https://github.com/hashicorp/go-version
+//
+// Versions have any number of numeric segments, padded to three, and an
+// optional prerelease that may omit its dash (1.7rc2). Constraints are
+// comma-separated comparators that must all match:
+//
+//	= != > < >= <= ~>
+//
+// A comparator without an operator is an exact match. ~> allows the rightmost
+// specified segment to increase, so ~> 1.2 means >= 1.2 < 2.0 and ~> 1.2.3
+// means >= 1.2.3 < 1.3.0. Prerelease versions only match comparators that name
+// a prerelease of the same segments.
+
+var terraformVersionRegex = regexp.MustCompile(
+	`^v?([0-9]+(?:\.[0-9]+)*?)` +
+		`(?:-([0-9]+[0-9A-Za-z\-~]*(?:\.[0-9A-Za-z\-~]+)*)|(-?[A-Za-z\-~]+[0-9A-Za-z\-~]*(?:\.[0-9A-Za-z\-~]+)*))?` +
+		`(?:\+([0-9A-Za-z\-~]+(?:\.[0-9A-Za-z\-~]+)*))?$`)
+
+const terraformMinimumSegments = 3
+
+type terraformVersion struct {
+	segments   []string
+	specified  int
+	prerelease string
+	metadata   string
+}
+
+func parseTerraformVersion(s string) (terraformVersion, bool) {
+	m := terraformVersionRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return terraformVersion{}, false
+	}
+	segments := strings.Split(m[1], ".")
+	specified := len(segments)
+	for i := range segments {
+		segments[i] = trimLeadingZeros(segments[i])
+	}
+	for len(segments) < terraformMinimumSegments {
+		segments = append(segments, "0")
+	}
+	prerelease := m[2]
+	if prerelease == "" {
+		prerelease = strings.TrimPrefix(m[3], "-")
+	}
+	return terraformVersion{segments: segments, specified: specified, prerelease: prerelease, metadata: m[4]}, true
+}
+
+// String formats the version the way go-version's String does.
+func (v terraformVersion) String() string {
+	s := strings.Join(v.segments, ".")
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	if v.metadata != "" {
+		s += "+" + v.metadata
+	}
+	return s
+}
+
+func normalizeTerraformVersion(version string) string {
+	v, _ := parseTerraformVersion(version)
+	return v.String()
+}
+
+// compareTerraform compares two versions using go-version semantics.
+// Missing segments compare as zero and build metadata is ignored.
+// Falls back to generic comparison if either side is not a valid version.
+func compareTerraform(a, b string) int {
+	left, leftOK := parseTerraformVersion(a)
+	right, rightOK := parseTerraformVersion(b)
+	if !leftOK || !rightOK {
+		return CompareVersions(a, b)
+	}
+	if c := compareTerraformSegments(left.segments, right.segments); c != 0 {
+		return c
+	}
+	switch {
+	case left.prerelease == right.prerelease:
+		return 0
+	case left.prerelease == "":
+		return 1
+	case right.prerelease == "":
+		return -1
+	}
+
+	leftParts := strings.Split(left.prerelease, ".")
+	rightParts := strings.Split(right.prerelease, ".")
+	for i := 0; i < len(leftParts) || i < len(rightParts); i++ {
+		var l, r string
+		if i < len(leftParts) {
+			l = leftParts[i]
+		}
+		if i < len(rightParts) {
+			r = rightParts[i]
+		}
+		if c := compareTerraformPrereleasePart(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareTerraformSegments(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		l, r := "0", "0"
+		if i < len(a) {
+			l = a[i]
+		}
+		if i < len(b) {
+			r = b[i]
+		}
+		if c := cmpNumStr(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareTerraformPrereleasePart mirrors go-version's comparePart. Unlike
+// SemVer, a missing part sorts after an alphanumeric one, so 5.4-alpha is
+// greater than 5.4-alpha.beta.
+func compareTerraformPrereleasePart(a, b string) int {
+	if a == b {
+		return 0
+	}
+	aNumeric, bNumeric := isDigits(a), isDigits(b)
+	switch {
+	case a == "":
+		if bNumeric {
+			return -1
+		}
+		return 1
+	case b == "":
+		if aNumeric {
+			return 1
+		}
+		return -1
+	case aNumeric && bNumeric:
+		return cmpNumStr(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return cmpString(a, b)
+	}
+}
+
+// terraformIntervalAllowsPrerelease reports whether a prerelease version may
+// match an interval. go-version only lets a prerelease through comparators
+// naming a prerelease with the same segments; != places no restriction.
+func terraformIntervalAllowsPrerelease(interval Interval, version string) bool {
+	candidate, ok := parseTerraformVersion(version)
+	if !ok {
+		return false
+	}
+	if candidate.prerelease == "" || (interval.Min == "" && interval.Max == "") {
+		return true
+	}
+	prereleaseBound := false
+	for _, bound := range []string{interval.Min, interval.Max} {
+		if bound == "" {
+			continue
+		}
+		parsed, ok := parseTerraformVersion(bound)
+		if !ok || compareTerraformSegments(parsed.segments, candidate.segments) != 0 {
+			return false
+		}
+		prereleaseBound = prereleaseBound || parsed.prerelease != ""
+	}
+	return prereleaseBound
+}
+
+// parseTerraformRange parses go-version constraint syntax.
+func (p *Parser) parseTerraformRange(constraint string) (*Range, error) {
+	if strings.TrimSpace(constraint) == "" {
+		return nil, fmt.Errorf("empty terraform constraint")
+	}
+
+	var result *Range
+	// go-version checks prereleases per comparator: a prerelease only matches
+	// when every ordering comparator names a prerelease of its segments.
+	prereleaseSegments, releasesOnly := "", false
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		operator := ""
+		for _, candidate := range []string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(part, candidate) {
+				operator = candidate
+				break
+			}
+		}
+		version := strings.TrimSpace(part[len(operator):])
+		v, ok := parseTerraformVersion(version)
+		if !ok {
+			return nil, fmt.Errorf("invalid terraform constraint: %s", part)
+		}
+
+		if operator != "" && operator != "=" && operator != "!=" {
+			segments := strings.Join(v.segments, ".")
+			switch {
+			case v.prerelease == "":
+				releasesOnly = true
+			case prereleaseSegments == "":
+				prereleaseSegments = segments
+			case prereleaseSegments != segments:
+				releasesOnly = true
+			}
+		}
+
+		r := &Range{Scheme: schemeTerraform}
+		switch operator {
+		case "", "=":
+			r.Intervals = []Interval{ExactInterval(version)}
+		case "!=":
+			r.Intervals = []Interval{UnboundedInterval()}
+			r.Exclusions = []string{version}
+		case ">":
+			r.Intervals = []Interval{GreaterThanInterval(version, false)}
+		case ">=":
+			r.Intervals = []Interval{GreaterThanInterval(version, true)}
+		case "<":
+			r.Intervals = []Interval{LessThanInterval(version, false)}
+		case "<=":
+			r.Intervals = []Interval{LessThanInterval(version, true)}
+		case "~>":
+			r.Intervals = []Interval{terraformPessimisticInterval(version, v)}
+		}
+		if result == nil {
+			result = r
+		} else {
+			result = result.Intersect(r)
+		}
+	}
+	if releasesOnly {
+		var intervals []Interval
+		for _, interval := range result.Intervals {
+			interval = terraformReleaseInterval(interval)
+			if !interval.isEmptyCmp(compareTerraform) {
+				intervals = append(intervals, interval)
+			}
+		}
+		result.Intervals = intervals
+	}
+	return result, nil
+}
+
+// terraformReleaseInterval replaces prerelease bounds with the release bounds
+// that admit the same releases, so >= 1.0.0-beta becomes >= 1.0.0 and
+// < 1.0.0-beta becomes < 1.0.0. Without a prerelease bound, the interval
+// admits no prereleases.
+func terraformReleaseInterval(interval Interval) Interval {
+	if v, ok := parseTerraformVersion(interval.Min); ok && v.prerelease != "" {
+		interval.Min, interval.MinInclusive = strings.Join(v.segments, "."), true
+	}
+	if v, ok := parseTerraformVersion(interval.Max); ok && v.prerelease != "" {
+		interval.Max, interval.MaxInclusive = strings.Join(v.segments, "."), false
+	}
+	return interval
+}
+
+// terraformPessimisticInterval expands ~>. A prerelease constraint only
+// matches prereleases of its own segments, so ~> 2.1.0-a is bounded by 2.1.0.
+func terraformPessimisticInterval(version string, v terraformVersion) Interval {
+	if v.prerelease != "" {
+		return NewInterval(version, strings.Join(v.segments, "."), true, false)
+	}
+	if v.specified == 1 {
+		return GreaterThanInterval(version, true)
+	}
+	upper := append([]string(nil), v.segments[:v.specified-1]...)
+	upper[len(upper)-1] = incNumStr(upper[len(upper)-1])
+	return NewInterval(version, strings.Join(upper, "."), true, false)
+}
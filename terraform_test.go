@@ -0,0 +1,124 @@
+package vers
+
+import "testing"
+
+func TestParseTerraformRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">= 1.0, < 1.2", "1.1.5", true},
+		{"< 1.0, < 1.2", "1.1.5", false},
+		{"= 1.0", "1.1.5", false},
+		{"= 1.0", "1.0.0", true},
+		{"1.0", "1.0.0", true},
+		{"~> 1.0", "2.0", false},
+		{"~> 1.0", "1.1", true},
+		{"~> 1.0", "1.2.3", true},
+		{"~> 1.0.0", "1.2.3", false},
+		{"~> 1.0.0", "1.0.7", true},
+		{"~> 1.0.0", "1.1.0", false},
+		{"~> 1.0.7", "1.0.4", false},
+		{"~> 1.0.7", "1.0.7", true},
+		{"~> 1.0.7", "1.0.8", true},
+		{"~> 1.0.7", "1.0.7.5", true},
+		{"~> 1.0.7", "1.0.6.99", false},
+		{"~> 1.0.7", "1.0.8.0", true},
+		{"~> 1.0.9.5", "1.0.9.5", true},
+		{"~> 1.0.9.5", "1.0.9.4", false},
+		{"~> 1.0.9.5", "1.0.9.6", true},
+		{"~> 1.0.9.5", "1.0.9.5.0", true},
+		{"~> 1.0.9.5", "1.0.10", false},
+		{"~> 1", "1.9", true},
+		{"~> 1", "2.0", true},
+		{"~> 2.0", "2.1.0-beta", false},
+		{"~> 2.1.0-a", "2.2.0", false},
+		{"~> 2.1.0-a", "2.1.0", false},
+		{"~> 2.1.0-a", "2.1.0-beta", true},
+		{"~> 2.1.0-a", "2.2.0-alpha", false},
+		{"> 2.0", "2.1.0-beta", false},
+		{">= 2.1.0-a", "2.1.0-beta", true},
+		{">= 2.1.0-a", "2.1.1-beta", false},
+		{">= 2.0.0", "2.1.0-beta", false},
+		{">= 2.1.0-a", "2.1.1", true},
+		{">= 2.1.0-a", "2.1.0", true},
+		{"<= 2.1.0-a", "2.0.0", true},
+		{"= 2.1.0-a", "2.1.0-a", true},
+		{"!= 1.3.0", "1.3", false},
+		{"!= 1.3.0", "1.4.0-beta", true},
+		{"~> 1.2, != 1.3.0", "1.3.0", false},
+		{"~> 1.2, != 1.3.0", "1.4.1", true},
+		{">=v1.0", "1.0.0", true},
+		{"= 1.7rc2", "1.7-rc2", true},
+		{">= 1.0.0-beta, >= 0.5", "1.0.0-rc", false},
+		{">= 1.0.0-beta, >= 0.5", "1.0.0", true},
+		{">= 1.0.0-beta, < 1.0.0", "1.0.0-rc", false},
+		{">= 1.0.0-beta, < 1.0.0-rc", "1.0.0-gamma", true},
+		{">= 1.0.0-beta, <= 2.0.0-beta", "1.0.0-rc", false},
+		{">= 1.0.0-beta, <= 2.0.0-beta", "1.5.0", true},
+		{"> 0.5, = 1.0.0-rc", "1.0.0-rc", false},
+		{"~> 2.1.0-a, >= 1.0", "2.1.0-beta", false},
+		{">= 1.0.0-beta, != 1.0.0-rc", "1.0.0-gamma", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseNative(tt.constraint, "terraform")
+		if err != nil {
+			t.Errorf("ParseNative(%q, terraform) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := r.Contains(tt.version); got != tt.want {
+			t.Errorf("ParseNative(%q, terraform).Contains(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseTerraformRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{"", ">= 1.0,", "~>", "^1.2", "=> 1.0", "1.0 || 2.0", "1.2.x", ">= 1.0 < 2.0"} {
+		if _, err := ParseNative(constraint, "terraform"); err == nil {
+			t.Errorf("ParseNative(%q, terraform) expected error", constraint)
+		}
+	}
+}
+
+func TestTerraformSchemeComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.4.5", -1},
+		{"1.2-beta", "1.2-beta", 0},
+		{"1.2", "1.1.4", 1},
+		{"1.2", "1.2-beta", 1},
+		{"1.2+foo", "1.2+beta", 0},
+		{"v1.2", "v1.2-beta", 1},
+		{"v1.2.0.0", "v1.2", 0},
+		{"v1.2.0.0.1", "v1.2", 1},
+		{"v1.2.3.0", "v1.2.3.4", -1},
+		{"1.7rc2", "1.7rc1", 1},
+		{"1.7rc2", "1.7", -1},
+		{"1.2.0", "1.2.0-X-1.2.0+metadata~dist", 1},
+		{"1.2-beta.2", "1.2-beta.11", -1},
+		{"3.2-alpha.1", "3.2-alpha", 1},
+		{"1.2-beta", "1.2-beta.3", -1},
+		{"3.0-alpha.1", "3.0-alpha.beta", -1},
+		{"5.4-alpha", "5.4-alpha.beta", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareWithScheme(tt.a, tt.b, "terraform"); got != tt.want {
+			t.Errorf("CompareWithScheme(%q, %q, terraform) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got, err := NormalizeWithScheme("v01.2", "opentofu"); err != nil || got != "1.2.0" {
+		t.Errorf("NormalizeWithScheme(v01.2, opentofu) = %q, %v", got, err)
+	}
+	if ValidWithScheme("1.2.x", "terraform") {
+		t.Error("ValidWithScheme(1.2.x, terraform) = true, want false")
+	}
+}
@@ -185,6 +185,86 @@ func extractGo(files map[string]string) ([]comparison, error) {
 	return requireComparisons(items)
 }
 
+// extractConda reads conda's VersionOrder table, which lists each version
+// with its parsed components in ascending order. Neighbours whose components
+// match after zero padding are equal.
+func extractConda(files map[string]string) ([]comparison, error) {
+	content := files["tests/models/test_version.py"]
+	start := strings.Index(content, "versions = [")
+	if start < 0 {
+		return nil, fmt.Errorf("versions table not found")
+	}
+	end := strings.Index(content[start:], "\n    ]")
+	if end < 0 {
+		return nil, fmt.Errorf("versions table not found")
+	}
+	row := regexp.MustCompile(`\(\s*"([^"]*)",\s*(\[.*\])\s*\)`)
+	matches := row.FindAllStringSubmatch(content[start:start+end], -1)
+	items := make([]comparison, 0, len(matches))
+	for index := 1; index < len(matches); index++ {
+		result := -1
+		if condaComponentsEqual(matches[index-1][2], matches[index][2]) {
+			result = 0
+		}
+		items = append(items, comparison{left: matches[index-1][1], right: matches[index][1], result: result})
+	}
+	return requireComparisons(items)
+}
+
+func condaComponentsEqual(left, right string) bool {
+	a, b := condaComponents(left), condaComponents(right)
+	for index := 0; index < len(a) || index < len(b); index++ {
+		var x, y []string
+		if index < len(a) {
+			x = a[index]
+		}
+		if index < len(b) {
+			y = b[index]
+		}
+		for part := 0; part < len(x) || part < len(y); part++ {
+			l, r := "0", "0"
+			if part < len(x) {
+				l = x[part]
+			}
+			if part < len(y) {
+				r = y[part]
+			}
+			if l != r {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func condaComponents(list string) [][]string {
+	inner := regexp.MustCompile(`\[([^\[\]]*)\]`)
+	var components [][]string
+	for _, match := range inner.FindAllStringSubmatch(list, -1) {
+		var parts []string
+		for _, part := range strings.Split(match[1], ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		components = append(components, parts)
+	}
+	return components
+}
+
+func extractGoVersion(files map[string]string) ([]comparison, error) {
+	content := files["version_test.go"]
+	row := regexp.MustCompile(`\{\s*"([^"]*)",\s*"([^"]*)",\s*(-?1|0),?\s*\}`)
+	items := make([]comparison, 0)
+	for _, name := range []string{"TestVersionCompare", "TestComparePreReleases"} {
+		for _, match := range row.FindAllStringSubmatch(goTestFunction(content, name), -1) {
+			result, _ := strconv.Atoi(match[3])
+			items = append(items, comparison{left: match[1], right: match[2], result: result})
+		}
+	}
+	return requireComparisons(items)
+}
+
 func extractNodeSemverRanges(files map[string]string) ([]nativeRangeAssertion, error) {
 	row := regexp.MustCompile(`^\s*\[\s*'([^']*)'\s*,\s*'([^']*)'(?:\s*,\s*(.*?))?\s*\],?(?:\s*//.*)?$`)
 	var assertions []nativeRangeAssertion
@@ -464,6 +544,16 @@ func extractCargoRanges(files map[string]string) ([]nativeRangeAssertion, error)
 	return requireRangeAssertions(assertions)
 }
 
+func extractGoVersionRanges(files map[string]string) ([]nativeRangeAssertion, error) {
+	content := goTestFunction(files["constraint_test.go"], "TestConstraintCheck")
+	row := regexp.MustCompile(`\{\s*"([^"]*)",\s*"([^"]*)",\s*(true|false),?\s*\}`)
+	assertions := make([]nativeRangeAssertion, 0)
+	for _, match := range row.FindAllStringSubmatch(content, -1) {
+		assertions = append(assertions, nativeRangeAssertion{nativeRange: match[1], version: match[2], contains: match[3] == "true"})
+	}
+	return requireRangeAssertions(assertions)
+}
+
 func extractMavenRanges(files map[string]string) ([]nativeRangeAssertion, error) {
 	content := files["compat/maven-artifact/src/test/java/org/apache/maven/artifact/versioning/VersionRangeTest.java"]
 	assignment := regexp.MustCompile(`(?:VersionRange\s+)?range\s*=\s*VersionRange\.createFromVersionSpec\("([^"]+)"\);`)
@@ -583,6 +673,19 @@ func functionBlock(content, marker string) string {
 	return rest[:end]
 }
 
+// goTestFunction returns the body of a top-level Go test function.
+func goTestFunction(content, name string) string {
+	start := strings.Index(content, "func "+name+"(")
+	if start < 0 {
+		return ""
+	}
+	rest := content[start:]
+	if end := strings.Index(rest, "\n}\n"); end >= 0 {
+		return rest[:end]
+	}
+	return rest
+}
+
 func debianVersion(epoch, version, revision string) string {
 	result := version
 	if epoch != "0" {
@@ -60,6 +60,16 @@ func TestExtractors(t *testing.T) {
 			files: map[string]string{"semver/semver_test.go": "var tests = []struct { in string; out string }{\n{\"bad\", \"\"},\n{\"also-bad\", \"\"},\n{\"v1\", \"v1.0.0\"},\n}\n"},
 			want:  []comparison{{left: "bad", right: "also-bad"}, {left: "also-bad", right: "v1", result: -1}},
 		},
+		{
+			name: "go-version", extract: extractGoVersion,
+			files: map[string]string{"version_test.go": "func TestVersionCompare(t *testing.T) {\n\tcases := []struct{}{\n\t\t{\"1.2\", \"1.2-beta\", 1},\n\t}\n}\n\nfunc TestComparePreReleases(t *testing.T) {\n\tcases := []struct{}{\n\t\t{\"3.2-alpha.1\", \"3.2-alpha\", 1},\n\t}\n}\n\nfunc TestVersionEqual(t *testing.T) {\n\t{\"1.0\", \"2.0\", -1},\n}\n"},
+			want:  []comparison{{left: "1.2", right: "1.2-beta", result: 1}, {left: "3.2-alpha.1", right: "3.2-alpha", result: 1}},
+		},
+		{
+			name: "conda", extract: extractConda,
+			files: map[string]string{"tests/models/test_version.py": "    versions = [\n        (\"   0.4\", [[0], [0], [4]]),\n        (\"0.4.0\", [[0], [0], [4], [0]]),\n        (\"0.4.1.rc\", [[0], [0], [4], [1], [0, \"rc\"]]),\n    ]\n"},
+			want:  []comparison{{left: "   0.4", right: "0.4.0"}, {left: "0.4.0", right: "0.4.1.rc", result: -1}},
+		},
 	}
 
 	for _, test := range tests {
@@ -206,6 +216,25 @@ assertFalse(range.containsVersion(new DefaultArtifactVersion("2.0")));
 				{nativeRange: "[1.0,2.0)", version: "2.0", contains: false},
 			},
 		},
+		{
+			name: "go-version", extract: extractGoVersionRanges,
+			files: map[string]string{"constraint_test.go": `
+func TestConstraintCheck(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		check      bool
+	}{
+		{">= 1.0, < 1.2", "1.1.5", true},
+		{"~> 2.0", "2.1.0-beta", false},
+	}
+}
+`},
+			want: []nativeRangeAssertion{
+				{nativeRange: ">= 1.0, < 1.2", version: "1.1.5", contains: true},
+				{nativeRange: "~> 2.0", version: "2.1.0-beta", contains: false},
+			},
+		},
 	}
 
 	for _, test := range tests {
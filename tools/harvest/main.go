@@ -201,6 +201,21 @@ var sources = []sourceSpec{
 		localPath: "golang/mod", sourceFiles: []string{"semver/semver_test.go"},
 		outputFile: "go_version_cmp_test.json", extract: extractGo,
 	},
+	{
+		name: "go-version", scheme: "terraform",
+		repository: "https://github.com/hashicorp/go-version.git",
+		commit:     "505335eb9df1a0063c4f4edadabbd4ba68a6039c", license: "MPL-2.0",
+		localPath: "hashicorp/go-version", sourceFiles: []string{"version_test.go", "constraint_test.go"},
+		outputFile: "terraform_version_cmp_test.json", extract: extractGoVersion,
+		rangeOutputFile: "terraform_range_reference_test.json", extractRanges: extractGoVersionRanges,
+	},
+	{
+		name: "conda", scheme: "conda",
+		repository: "https://github.com/conda/conda.git",
+		commit:     "24.1.2", license: "BSD-3-Clause",
+		localPath: "conda/conda", sourceFiles: []string{"tests/models/test_version.py"},
+		outputFile: "conda_version_cmp_test.json", extract: extractConda,
+	},
 	{
 		name:       "univers",
 		repository: "https://github.com/package-url/univers.git",
@@ -44,7 +44,8 @@ func Parse(versURI string) (*Range, error) {
 //   - pypi: >=1.0,<2.0, ~=1.4.2, !=1.5.0
 //   - pub: ^1.2.3, >=1.2.3 <2.0.0, any
 //   - maven: [1.0,2.0), (1.0,2.0], [1.0,)
-//   - nuget: [1.0,2.0), (1.0,2.0]
+//   - gradle: 1.+, [1.0,2.0[, ]1.0,2.0], latest.release, 1.7!!
+//   - nuget: [1.0,2.0), (1.0, 2.0], [1.0], 1.*, 1.2.*, 1.0.0-*, *-*
 //   - cargo: ^1.2.3, ~1.2.3, >=1.0.0, <2.0.0
 //   - go: >=1.0.0, <2.0.0
 //   - hex/elixir: ~> 1.2, >= 1.0 and < 2.0
@@ -53,6 +54,9 @@ func Parse(versURI string) (*Range, error) {
 //   - conan: ^1.2, ~1.2, >1 <2, ||
 //   - openssl: exact versions, optionally comma-separated
 //   - nginx: 0.8.40+, 0.7.52-0.8.39
+//   - hackage: ^>=1.2.3, ==1.2.*, >=1 && <1.5 || -none, -any
+//   - conda: 1.2.*, >=1.0,<2|3.0, 1.2|1.4, !=1.5, ~=1.2.3, ==1.2.3
+//   - terraform, opentofu: ~> 1.2, >= 1.0, < 2.0, != 1.3.0, = 1.2.3
 func ParseNative(constraint string, scheme string) (*Range, error) {
 	return defaultParser.ParseNative(constraint, scheme)
 }
@@ -83,6 +87,24 @@ func Compare(a, b string) int {
 	return CompareVersions(a, b)
 }
 
+// SelectOption adjusts how HighestSatisfying, LowestSatisfying and
+// FilterSatisfying choose among satisfying versions.
+type SelectOption func(*selectOptions)
+
+type selectOptions struct {
+	preferStable bool
+}
+
+// PreferStable ignores satisfying prereleases when at least one stable
+// version satisfies the constraint, falling back to prereleases only when
+// nothing stable matches, as npm and pip do. Prereleases are judged by the
+// scheme's own rules.
+func PreferStable() SelectOption {
+	return func(o *selectOptions) {
+		o.preferStable = true
+	}
+}
+
 // HighestSatisfying returns the highest version in versions that
 // satisfies constraint under the given scheme. Versions that fail to
 // parse are skipped. Returns ("", nil) when no version in the list
@@ -94,7 +116,50 @@ func Compare(a, b string) int {
 // that still satisfies the user's manifest constraint.
 //
 // If scheme is empty, constraint is parsed as a vers URI.
-func HighestSatisfying(versions []string, constraint, scheme string) (string, error) {
+func HighestSatisfying(versions []string, constraint, scheme string, opts ...SelectOption) (string, error) {
+	return selectSatisfying(versions, constraint, scheme, 1, opts)
+}
+
+// LowestSatisfying returns the lowest version in versions that satisfies
+// constraint, the choice made by Go's minimal version selection and
+// Composer's --prefer-lowest. It follows the same rules as
+// HighestSatisfying.
+func LowestSatisfying(versions []string, constraint, scheme string, opts ...SelectOption) (string, error) {
+	return selectSatisfying(versions, constraint, scheme, -1, opts)
+}
+
+// FilterSatisfying returns the versions that satisfy constraint, in their
+// original order. If scheme is empty, constraint is parsed as a vers URI.
+func FilterSatisfying(versions []string, constraint, scheme string, opts ...SelectOption) ([]string, error) {
+	matches, _, err := satisfyingVersions(versions, constraint, scheme, opts)
+	return matches, err
+}
+
+// selectSatisfying returns the satisfying version that compares furthest in
+// direction: 1 for the highest, -1 for the lowest.
+func selectSatisfying(versions []string, constraint, scheme string, direction int, opts []SelectOption) (string, error) {
+	matches, effectiveScheme, err := satisfyingVersions(versions, constraint, scheme, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var best string
+	for _, v := range matches {
+		if best == "" || CompareWithScheme(v, best, effectiveScheme)*direction > 0 {
+			best = v
+		}
+	}
+	return best, nil
+}
+
+// satisfyingVersions filters versions by constraint and returns the scheme
+// the versions compare under.
+func satisfyingVersions(versions []string, constraint, scheme string, opts []SelectOption) ([]string, string, error) {
+	var options selectOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	var r *Range
 	var err error
 	if scheme == "" {
@@ -103,23 +168,27 @@ func HighestSatisfying(versions []string, constraint, scheme string) (string, er
 		r, err = ParseNative(constraint, scheme)
 	}
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 	effectiveScheme := scheme
 	if effectiveScheme == "" {
 		effectiveScheme = r.Scheme
 	}
 
-	var best string
+	var matches, stable []string
 	for _, v := range versions {
 		if !r.Contains(v) {
 			continue
 		}
-		if best == "" || CompareWithScheme(v, best, effectiveScheme) > 0 {
-			best = v
+		matches = append(matches, v)
+		if options.preferStable && !isPrereleaseForScheme(v, effectiveScheme) {
+			stable = append(stable, v)
 		}
 	}
-	return best, nil
+	if len(stable) > 0 {
+		return stable, effectiveScheme, nil
+	}
+	return matches, effectiveScheme, nil
 }
 
 // ValidWithScheme checks whether a version is valid for the given scheme.
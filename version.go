@@ -16,6 +16,7 @@ const (
 	schemeCargo         = "cargo"
 	schemeComposer      = "composer"
 	schemeConan         = "conan"
+	schemeConda         = "conda"
 	schemeDatetime      = "datetime"
 	schemeDeb           = "deb"
 	schemeDebian        = "debian"
@@ -24,6 +25,8 @@ const (
 	schemeGentoo        = "gentoo"
 	schemeGo            = "go"
 	schemeGolang        = "golang"
+	schemeGradle        = "gradle"
+	schemeHackage       = "hackage"
 	schemeHex           = "hex"
 	schemeIntDot        = "intdot"
 	schemeLexicographic = "lexicographic"
@@ -37,6 +40,8 @@ const (
 	schemeRPM           = "rpm"
 	schemeRubyGems      = "rubygems"
 	schemeSemVer        = "semver"
+	schemeTerraform     = "terraform"
+	schemeOpenTofu      = "opentofu"
 	qualifierAlpha      = "alpha"
 	qualifierBeta       = "beta"
 	qualifierPre        = "pre"
@@ -368,6 +373,8 @@ func compareFuncFor(scheme string) func(a, b string) int {
 		return compareNuGet
 	case schemeMaven:
 		return compareMaven
+	case schemeGradle:
+		return compareGradle
 	case schemePyPI:
 		return comparePyPI
 	case schemeLexicographic:
@@ -376,6 +383,8 @@ func compareFuncFor(scheme string) func(a, b string) int {
 		return compareDatetime
 	case schemeIntDot:
 		return compareIntDot
+	case schemeHackage:
+		return compareHackage
 	case schemeAPK, schemeAlpine:
 		// This covers the vendored Alpine cases, but is not a full apk-tools
 		// implementation; APK has additional VCS suffix and letter rules.
@@ -386,6 +395,10 @@ func compareFuncFor(scheme string) func(a, b string) int {
 		return compareALPM
 	case schemeConan:
 		return compareConan
+	case schemeConda:
+		return compareConda
+	case schemeTerraform, schemeOpenTofu:
+		return compareTerraform
 	case schemeOpenSSL:
 		return compareOpenSSL
 	default:
@@ -414,6 +427,8 @@ func canonicalScheme(scheme string) string {
 		return schemeHex
 	case schemeAlpine:
 		return schemeAPK
+	case schemeOpenTofu:
+		return schemeTerraform
 	default:
 		return scheme
 	}